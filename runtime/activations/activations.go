@@ -19,6 +19,9 @@
 package activations
 
 import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -29,6 +32,7 @@ import (
 type Activation[T any] struct {
 	MemoryGauge common.MemoryGauge
 	entries     map[string]T
+	positions   map[string]ast.Position
 	Parent      *Activation[T]
 	Depth       int
 	IsFunction  bool
@@ -70,6 +74,16 @@ func (a *Activation[T]) Find(name string) (_ T) {
 	return
 }
 
+// FindLocal returns the value for a given name in the activation itself,
+// without considering parent activations.
+// It returns the zero value of T if no value is found.
+func (a *Activation[T]) FindLocal(name string) (_ T) {
+	if a.entries == nil {
+		return
+	}
+	return a.entries[name]
+}
+
 // FunctionValues returns all values in the current function activation.
 func (a *Activation[T]) FunctionValues() map[string]T {
 
@@ -97,6 +111,82 @@ func (a *Activation[T]) FunctionValues() map[string]T {
 	return values
 }
 
+// CapturedValues returns the values for the given referenced names
+// which are not declared in the current function activation,
+// but in an enclosing function activation, i.e. the free variables captured by the function.
+// Unlike FunctionValues, it walks past function boundaries.
+// Names that are declared in the current function, or not declared at all, are not included.
+// Names that are declared in the given globals activation, or in its parents,
+// e.g. a base activation which declares the built-ins, are not included either,
+// as they are not captured. If the globals activation is nil, all enclosing activations are considered.
+func (a *Activation[T]) CapturedValues(globals *Activation[T], referencedNames []string) map[string]T {
+
+	values := make(map[string]T)
+
+	// Find the activation of the current function,
+	// i.e. the boundary after which declarations are captured
+
+	function := a
+	for function != nil && !function.IsFunction {
+		function = function.Parent
+	}
+
+	if function == nil {
+		return values
+	}
+
+	for _, name := range referencedNames {
+		if _, ok := values[name]; ok {
+			continue
+		}
+
+		// Declarations in the current function shadow captured declarations
+
+		if a.findInFunction(name) {
+			continue
+		}
+
+		current := function.Parent
+
+		for current != nil && current != globals {
+			if current.entries != nil {
+				value, ok := current.entries[name]
+				if ok {
+					values[name] = value
+					break
+				}
+			}
+
+			current = current.Parent
+		}
+	}
+
+	return values
+}
+
+// findInFunction returns true if the given name is declared in the current function activation.
+func (a *Activation[T]) findInFunction(name string) bool {
+
+	current := a
+
+	for current != nil {
+
+		if current.entries != nil {
+			if _, ok := current.entries[name]; ok {
+				return true
+			}
+		}
+
+		if current.IsFunction {
+			break
+		}
+
+		current = current.Parent
+	}
+
+	return false
+}
+
 // Set sets the given name-value pair in the activation.
 func (a *Activation[T]) Set(name string, value T) {
 	if a.entries == nil {
@@ -105,6 +195,46 @@ func (a *Activation[T]) Set(name string, value T) {
 	}
 
 	a.entries[name] = value
+
+	// A previously recorded position belongs to a previous declaration
+	if a.positions != nil {
+		delete(a.positions, name)
+	}
+}
+
+// SetWithPosition sets the given name-value pair in the activation,
+// and records the given source position of the declaration of the name.
+func (a *Activation[T]) SetWithPosition(name string, value T, pos ast.Position) {
+	a.Set(name, value)
+
+	if a.positions == nil {
+		a.positions = make(map[string]ast.Position)
+	}
+
+	a.positions[name] = pos
+}
+
+// FindPosition returns the source position of the declaration of the given name
+// in the activation, or in its parent activations.
+// It returns false if no value is found for the name,
+// or if the value was set without a position.
+func (a *Activation[T]) FindPosition(name string) (ast.Position, bool) {
+
+	current := a
+
+	for current != nil {
+
+		if current.entries != nil {
+			if _, ok := current.entries[name]; ok {
+				pos, ok := current.positions[name]
+				return pos, ok
+			}
+		}
+
+		current = current.Parent
+	}
+
+	return ast.Position{}, false
 }
 
 // Activations is a stack of activation records.
@@ -159,6 +289,31 @@ func (a *Activations[T]) Set(name string, value T) {
 	current.Set(name, value)
 }
 
+// SetWithPosition sets the name-value pair in the current scope,
+// and records the given source position of the declaration of the name.
+func (a *Activations[T]) SetWithPosition(name string, value T, pos ast.Position) {
+	current := a.Current()
+	// create the first scope if there is no scope
+	if current == nil {
+		current = a.PushNewWithParent(nil)
+	}
+
+	current.SetWithPosition(name, value, pos)
+}
+
+// FindPosition returns the source position of the declaration of the given name
+// in the current activation.
+// It returns false if no value is found for the name,
+// if the value was set without a position,
+// or if there is no current activation.
+func (a *Activations[T]) FindPosition(name string) (ast.Position, bool) {
+	current := a.Current()
+	if current == nil {
+		return ast.Position{}, false
+	}
+	return current.FindPosition(name)
+}
+
 // PushNewWithParent pushes a new empty activation
 // to the top of the activation stack.
 // The new activation has the given parent as its parent.
@@ -196,6 +351,16 @@ func (a *Activations[T]) Pop() {
 	a.activations = a.activations[:lastIndex]
 }
 
+// Clear pops all activations from the activation stack,
+// so the activation stack can be reused.
+func (a *Activations[T]) Clear() {
+	// Release the activations, so they can be garbage collected
+	for i := range a.activations {
+		a.activations[i] = nil
+	}
+	a.activations = a.activations[:0]
+}
+
 // CurrentOrNew returns the current activation,
 // or if it does not exist, a new activation
 func (a *Activations[T]) CurrentOrNew() *Activation[T] {
@@ -211,3 +376,47 @@ func (a *Activations[T]) CurrentOrNew() *Activation[T] {
 func (a *Activations[T]) Depth() int {
 	return len(a.activations)
 }
+
+// Mark returns the current depth of the activation stack,
+// which can later be restored using RestoreTo,
+// e.g. to unwind to a known scope during error recovery.
+func (a *Activations[T]) Mark() int {
+	return a.Depth()
+}
+
+// InvalidMarkError is returned by RestoreTo
+// if the activation stack cannot be restored to the given mark.
+type InvalidMarkError struct {
+	Mark  int
+	Depth int
+}
+
+func (e InvalidMarkError) Error() string {
+	return fmt.Sprintf(
+		"cannot restore activations to depth %d, current depth is %d",
+		e.Mark,
+		e.Depth,
+	)
+}
+
+// RestoreTo pops activations from the activation stack,
+// until it has the given depth, as returned by Mark.
+// It returns an error, and leaves the activation stack unchanged,
+// if the activation stack is not at least as deep as the given depth.
+func (a *Activations[T]) RestoreTo(mark int) error {
+	count := len(a.activations)
+	if mark < 0 || mark > count {
+		return InvalidMarkError{
+			Mark:  mark,
+			Depth: count,
+		}
+	}
+
+	// Release the popped activations, so they can be garbage collected
+	for i := mark; i < count; i++ {
+		a.activations[i] = nil
+	}
+	a.activations = a.activations[:mark]
+
+	return nil
+}
@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
 )
 
 func TestActivations(t *testing.T) {
@@ -71,3 +74,378 @@ func TestActivations(t *testing.T) {
 	assert.Zero(t, activations.Find("b"))
 	assert.Zero(t, activations.Find("c"))
 }
+
+func TestActivationsClear(t *testing.T) {
+
+	t.Parallel()
+
+	activations := &Activations[int]{}
+
+	activations.Set("a", 1)
+	activations.PushNewWithCurrent()
+	activations.Set("b", 2)
+
+	assert.Equal(t, 2, activations.Depth())
+
+	activations.Clear()
+
+	assert.Equal(t, 0, activations.Depth())
+	assert.Nil(t, activations.Current())
+	assert.Zero(t, activations.Find("a"))
+	assert.Zero(t, activations.Find("b"))
+
+	// Setting creates a fresh scope
+
+	activations.Set("c", 3)
+
+	assert.Equal(t, 1, activations.Depth())
+	assert.Nil(t, activations.Current().Parent)
+	assert.Equal(t, 3, activations.Find("c"))
+	assert.Zero(t, activations.Find("a"))
+	assert.Zero(t, activations.Find("b"))
+}
+
+func TestActivationsRestoreTo(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("interleaved pushes and restores", func(t *testing.T) {
+
+		t.Parallel()
+
+		activations := &Activations[int]{}
+
+		activations.Set("a", 1)
+		outer := activations.Mark()
+		assert.Equal(t, 1, outer)
+
+		activations.PushNewWithCurrent()
+		activations.Set("b", 2)
+		inner := activations.Mark()
+		assert.Equal(t, 2, inner)
+
+		activations.PushNewWithCurrent()
+		activations.Set("c", 3)
+		activations.PushNewWithCurrent()
+
+		// Restoring to the inner mark pops the two nested activations
+
+		err := activations.RestoreTo(inner)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, activations.Depth())
+		assert.Equal(t, 2, activations.Find("b"))
+		assert.Zero(t, activations.Find("c"))
+
+		// Restoring to the current depth is a no-op
+
+		err = activations.RestoreTo(inner)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, activations.Depth())
+
+		// Push again after restoring, then restore to the outer mark
+
+		activations.PushNewWithCurrent()
+		activations.Set("d", 4)
+		assert.Equal(t, 3, activations.Depth())
+
+		err = activations.RestoreTo(outer)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, activations.Depth())
+		assert.Equal(t, 1, activations.Find("a"))
+		assert.Zero(t, activations.Find("b"))
+		assert.Zero(t, activations.Find("d"))
+
+		err = activations.RestoreTo(0)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, activations.Depth())
+		assert.Nil(t, activations.Current())
+	})
+
+	t.Run("invalid mark", func(t *testing.T) {
+
+		t.Parallel()
+
+		activations := &Activations[int]{}
+
+		activations.Set("a", 1)
+		activations.PushNewWithCurrent()
+		inner := activations.Mark()
+
+		err := activations.RestoreTo(1)
+		require.NoError(t, err)
+
+		// The stack cannot be restored to a depth deeper than the current depth,
+		// or to a negative depth
+
+		err = activations.RestoreTo(inner)
+		require.Equal(t, InvalidMarkError{Mark: 2, Depth: 1}, err)
+		require.EqualError(t, err, "cannot restore activations to depth 2, current depth is 1")
+
+		err = activations.RestoreTo(-1)
+		require.Equal(t, InvalidMarkError{Mark: -1, Depth: 1}, err)
+
+		// The stack is left unchanged
+
+		assert.Equal(t, 1, activations.Depth())
+		assert.Equal(t, 1, activations.Find("a"))
+	})
+}
+
+func TestActivationFindLocal(t *testing.T) {
+
+	t.Parallel()
+
+	activations := &Activations[int]{}
+
+	activations.Set("a", 1)
+
+	assert.Equal(t, 1, activations.Current().FindLocal("a"))
+	assert.Zero(t, activations.Current().FindLocal("b"))
+
+	activations.PushNewWithCurrent()
+
+	// Declared in outer scope only
+
+	assert.Zero(t, activations.Current().FindLocal("a"))
+	assert.Equal(t, 1, activations.Find("a"))
+
+	// Declared in current scope, shadowing the outer declaration
+
+	activations.Set("a", 2)
+	activations.Set("b", 3)
+
+	assert.Equal(t, 2, activations.Current().FindLocal("a"))
+	assert.Equal(t, 3, activations.Current().FindLocal("b"))
+	assert.Equal(t, 1, activations.Current().Parent.FindLocal("a"))
+	assert.Zero(t, activations.Current().Parent.FindLocal("b"))
+}
+
+func TestActivationSetWithPosition(t *testing.T) {
+
+	t.Parallel()
+
+	activations := &Activations[int]{}
+
+	posA := ast.Position{Offset: 1, Line: 1, Column: 1}
+	activations.SetWithPosition("a", 1, posA)
+	activations.Set("b", 2)
+
+	activations.PushNewWithCurrent()
+	activations.PushNewWithCurrent()
+
+	// The position is retained through parent lookups
+
+	assert.Equal(t, 1, activations.Find("a"))
+
+	pos, ok := activations.FindPosition("a")
+	require.True(t, ok)
+	assert.Equal(t, posA, pos)
+
+	// No position was recorded
+
+	_, ok = activations.FindPosition("b")
+	assert.False(t, ok)
+
+	// Not declared
+
+	_, ok = activations.FindPosition("c")
+	assert.False(t, ok)
+
+	// A shadowing declaration has its own position
+
+	posA2 := ast.Position{Offset: 10, Line: 2, Column: 5}
+	activations.SetWithPosition("a", 3, posA2)
+
+	pos, ok = activations.FindPosition("a")
+	require.True(t, ok)
+	assert.Equal(t, posA2, pos)
+
+	// Re-declaring without a position clears the previous position
+
+	activations.Set("a", 4)
+
+	_, ok = activations.FindPosition("a")
+	assert.False(t, ok)
+
+	activations.Pop()
+
+	pos, ok = activations.FindPosition("a")
+	require.True(t, ok)
+	assert.Equal(t, posA, pos)
+}
+
+func TestActivationCapturedValues(t *testing.T) {
+
+	t.Parallel()
+
+	activations := &Activations[int]{}
+
+	// Program scope
+
+	activations.Set("global", 1)
+	activations.Set("shadowed", 2)
+
+	globals := activations.Current()
+
+	// Outer function
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+	activations.Set("outer", 3)
+	activations.Set("shadowed", 4)
+
+	// Block in outer function
+
+	activations.PushNewWithCurrent()
+	activations.Set("outerBlock", 5)
+
+	// Inner function
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+	activations.Set("inner", 6)
+
+	// Block in inner function
+
+	activations.PushNewWithCurrent()
+	activations.Set("innerBlock", 7)
+
+	current := activations.Current()
+
+	referencedNames := []string{
+		"global",
+		"shadowed",
+		"outer",
+		"outerBlock",
+		"inner",
+		"innerBlock",
+		"undeclared",
+		"outer",
+	}
+
+	// Globals are not captured, but declarations shadowing them are
+
+	assert.Equal(t,
+		map[string]int{
+			"shadowed":   4,
+			"outer":      3,
+			"outerBlock": 5,
+		},
+		current.CapturedValues(globals, referencedNames),
+	)
+
+	// Function values stop at the function boundary
+
+	assert.Equal(t,
+		map[string]int{
+			"inner":      6,
+			"innerBlock": 7,
+		},
+		current.FunctionValues(),
+	)
+
+	// A declaration in the inner function shadows the captured declaration
+
+	current.Set("outer", 8)
+
+	assert.Equal(t,
+		map[string]int{
+			"shadowed":   4,
+			"outerBlock": 5,
+		},
+		current.CapturedValues(globals, referencedNames),
+	)
+
+	// The outer function only refers to globals, so it captures nothing
+
+	activations.Pop()
+	activations.Pop()
+
+	assert.Empty(t,
+		activations.Current().CapturedValues(globals, referencedNames),
+	)
+}
+
+func TestActivationCapturedValuesGlobal(t *testing.T) {
+
+	t.Parallel()
+
+	activations := &Activations[int]{}
+
+	// Program scope
+
+	activations.Set("global", 1)
+
+	globals := activations.Current()
+
+	// Function declared in the program scope
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+	activations.Set("local", 2)
+
+	// Closure in the function, which refers to the global
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+
+	assert.Equal(t,
+		map[string]int{
+			"local": 2,
+		},
+		activations.Current().CapturedValues(globals, []string{"global", "local"}),
+	)
+}
+
+func TestActivationCapturedValuesBaseActivation(t *testing.T) {
+
+	t.Parallel()
+
+	// Like the interpreter, declare the built-ins in a base activation,
+	// and the globals in a child activation of it
+
+	base := NewActivation[int](nil, nil)
+	base.Set("builtin", 1)
+
+	activations := &Activations[int]{}
+
+	// Program scope
+
+	globals := activations.PushNewWithParent(base)
+	activations.Set("global", 2)
+
+	// Function declared in the program scope
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+	activations.Set("local", 3)
+
+	// Closure in the function, which refers to the built-in and the global
+
+	activations.PushNewWithCurrent()
+	activations.Current().IsFunction = true
+
+	referencedNames := []string{"builtin", "global", "local"}
+
+	assert.Equal(t,
+		map[string]int{
+			"local": 3,
+		},
+		activations.Current().CapturedValues(globals, referencedNames),
+	)
+
+	// Without a globals activation, all enclosing declarations are captured
+
+	assert.Equal(t,
+		map[string]int{
+			"builtin": 1,
+			"global":  2,
+			"local":   3,
+		},
+		activations.Current().CapturedValues(nil, referencedNames),
+	)
+}
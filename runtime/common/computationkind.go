@@ -18,6 +18,12 @@
 
 package common
 
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/errors"
+)
+
 //go:generate go run golang.org/x/tools/cmd/stringer -type=ComputationKind -trimprefix=ComputationKind
 
 // ComputationKind captures kind of computation that would be used for metring computation
@@ -25,6 +31,7 @@ type ComputationKind uint
 
 // [1000,2000) is reserved for Cadence interpreter and runtime
 const ComputationKindRangeStart = 1000
+const ComputationKindRangeEnd = 2000
 
 const (
 	ComputationKindUnknown ComputationKind = 0
@@ -84,7 +91,7 @@ const (
 	_
 	_
 	_
-	_
+	ComputationKindStringOperation
 	_
 	_
 	_
@@ -143,3 +150,41 @@ const (
 	ComputationKindSTDLIBRLPDecodeString
 	ComputationKindSTDLIBRLPDecodeList
 )
+
+var computationKindNamesLock sync.RWMutex
+var computationKindNames = map[ComputationKind]string{}
+
+// RegisterComputationKind registers the name of a computation kind defined by the embedder,
+// e.g. to meter custom standard library functions.
+// The kind must be above the range reserved for Cadence, and may only be registered once.
+func RegisterComputationKind(kind ComputationKind, name string) error {
+	if kind < ComputationKindRangeEnd {
+		return errors.NewUnexpectedError("cannot register reserved computation kind: %d", kind)
+	}
+
+	computationKindNamesLock.Lock()
+	defer computationKindNamesLock.Unlock()
+
+	if _, ok := computationKindNames[kind]; ok {
+		return errors.NewUnexpectedError("cannot register already registered computation kind: %d", kind)
+	}
+	computationKindNames[kind] = name
+
+	return nil
+}
+
+// Name returns the name of the computation kind.
+// Unlike String, it also returns the names of computation kinds registered by the embedder.
+func (i ComputationKind) Name() string {
+	if i >= ComputationKindRangeEnd {
+		computationKindNamesLock.RLock()
+		name, ok := computationKindNames[i]
+		computationKindNamesLock.RUnlock()
+
+		if ok {
+			return name
+		}
+	}
+
+	return i.String()
+}
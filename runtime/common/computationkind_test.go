@@ -0,0 +1,81 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unregisterComputationKind removes the registration of the given computation kind,
+// so tests can register it again when they are run repeatedly.
+func unregisterComputationKind(kind ComputationKind) {
+	computationKindNamesLock.Lock()
+	defer computationKindNamesLock.Unlock()
+
+	delete(computationKindNames, kind)
+}
+
+func TestRegisterComputationKind(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("custom", func(t *testing.T) {
+
+		t.Parallel()
+
+		const kind ComputationKind = 2_000_001
+
+		assert.Equal(t, "ComputationKind(2000001)", kind.Name())
+
+		err := RegisterComputationKind(kind, "CustomFunction")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			unregisterComputationKind(kind)
+		})
+
+		assert.Equal(t, "CustomFunction", kind.Name())
+
+		// The generated String function is not affected by the registration
+		assert.Equal(t, "ComputationKind(2000001)", kind.String())
+
+		err = RegisterComputationKind(kind, "OtherFunction")
+		require.Error(t, err)
+		assert.Equal(t, "CustomFunction", kind.Name())
+	})
+
+	t.Run("reserved", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := RegisterComputationKind(ComputationKindUnknown, "Custom")
+		require.Error(t, err)
+
+		err = RegisterComputationKind(ComputationKindRangeStart+999, "Custom")
+		require.Error(t, err)
+
+		err = RegisterComputationKind(ComputationKindRangeStart-1, "Custom")
+		require.Error(t, err)
+
+		assert.Equal(t, "Statement", ComputationKindStatement.Name())
+		assert.Equal(t, "Statement", ComputationKindStatement.String())
+	})
+}
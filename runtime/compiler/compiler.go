@@ -43,13 +43,13 @@ func NewCompiler(checker *sema.Checker) *Compiler {
 	}
 }
 
-// declareLocal declares a local
-func (compiler *Compiler) declareLocal(identifier string, valType ir.ValType) *Local {
+// declareLocal declares a local, which is declared at the given position
+func (compiler *Compiler) declareLocal(identifier string, valType ir.ValType, pos ast.Position) *Local {
 	// NOTE: semantic analysis already checked possible invalid redeclaration
 	index := uint32(len(compiler.locals))
 	local := NewLocal(index, valType)
 	compiler.locals = append(compiler.locals, local)
-	compiler.setLocal(identifier, local)
+	compiler.setLocalWithPosition(identifier, local, pos)
 	return local
 }
 
@@ -61,6 +61,13 @@ func (compiler *Compiler) setLocal(name string, variable *Local) {
 	compiler.activations.Set(name, variable)
 }
 
+// setLocalWithPosition sets the local in the current scope,
+// and records the source position of its declaration
+func (compiler *Compiler) setLocalWithPosition(name string, variable *Local, pos ast.Position) {
+	variable.Pos = &pos
+	compiler.activations.SetWithPosition(name, variable, pos)
+}
+
 func (compiler *Compiler) VisitReturnStatement(statement *ast.ReturnStatement) ir.Stmt {
 	exp := ast.AcceptExpression[ir.Expr](statement.Expression, compiler)
 	return &ir.Return{
@@ -117,7 +124,7 @@ func (compiler *Compiler) VisitVariableDeclaration(declaration *ast.VariableDecl
 	identifier := declaration.Identifier.Identifier
 	targetType := compiler.Checker.Elaboration.VariableDeclarationTypes(declaration).TargetType
 	valType := compileValueType(targetType)
-	local := compiler.declareLocal(identifier, valType)
+	local := compiler.declareLocal(identifier, valType, declaration.Identifier.Pos)
 	exp := ast.AcceptExpression[ir.Expr](declaration.Value, compiler)
 
 	return &ir.StoreLocal{
@@ -312,7 +319,7 @@ func (compiler *Compiler) VisitFunctionDeclaration(declaration *ast.FunctionDecl
 		parameterType := functionType.Parameters[i].TypeAnnotation.Type
 		valType := compileValueType(parameterType)
 		name := parameter.Identifier.Identifier
-		compiler.declareLocal(name, valType)
+		compiler.declareLocal(name, valType, parameter.Identifier.Pos)
 	}
 
 	// Compile the function block
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/compiler/ir"
 	"github.com/onflow/cadence/runtime/tests/checker"
 )
@@ -81,3 +82,54 @@ func TestCompilerSimple(t *testing.T) {
 		res,
 	)
 }
+
+func TestCompilerLocalPositions(t *testing.T) {
+
+	checker, err := checker.ParseAndCheck(t, `
+      fun inc(a: Int): Int {
+          let mod = 1
+          return a + mod
+      }
+    `)
+
+	require.NoError(t, err)
+
+	compiler := NewCompiler(checker)
+
+	compiler.VisitFunctionDeclaration(checker.Program.FunctionDeclarations()[0])
+
+	require.Len(t, compiler.locals, 2)
+
+	// The parameter
+
+	require.Equal(t,
+		&ast.Position{Offset: 15, Line: 2, Column: 14},
+		compiler.locals[0].Pos,
+	)
+
+	// The variable declaration
+
+	require.Equal(t,
+		&ast.Position{Offset: 44, Line: 3, Column: 14},
+		compiler.locals[1].Pos,
+	)
+}
+
+func TestCompilerLocalPositionInParentScope(t *testing.T) {
+
+	compiler := NewCompiler(nil)
+
+	pos := ast.Position{Offset: 1, Line: 2, Column: 3}
+	local := compiler.declareLocal("a", ir.ValTypeInt, pos)
+
+	compiler.activations.PushNewWithCurrent()
+	defer compiler.activations.Pop()
+
+	found := compiler.findLocal("a")
+	require.Same(t, local, found)
+	require.Equal(t, &pos, found.Pos)
+
+	foundPos, ok := compiler.activations.FindPosition("a")
+	require.True(t, ok)
+	require.Equal(t, pos, foundPos)
+}
@@ -19,12 +19,15 @@
 package compiler
 
 import (
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/compiler/ir"
 )
 
 type Local struct {
 	Index uint32
 	Type  ir.ValType
+	// Pos is the source position of the declaration of the local, if known
+	Pos *ast.Position
 }
 
 func NewLocal(index uint32, valType ir.ValType) *Local {
@@ -39,4 +39,6 @@ type Config struct {
 	AccountLinkingEnabled bool
 	// AttachmentsEnabled specifies if attachments are enabled
 	AttachmentsEnabled bool
+	// OptionalArgumentsEnabled specifies if trailing optional entry point arguments may be omitted
+	OptionalArgumentsEnabled bool
 }
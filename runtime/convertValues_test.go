@@ -1392,6 +1392,54 @@ func TestExportAddressValue(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestExportNestedAddressValues(t *testing.T) {
+
+	t.Parallel()
+
+	// Addresses are always exported as 8 bytes,
+	// also when nested in arrays and dictionaries of AnyStruct,
+	// as they are e.g. when passed as test arguments.
+	// NOTE: without an expected type of Address, e.g. in an AnyStruct context,
+	// hexadecimal literals are integers, so they must be cast
+
+	script := `
+        pub fun main(): [AnyStruct] {
+            return [
+                0x1 as Address,
+                [0x2 as Address],
+                {"recipient": 0x0000000000000003 as Address}
+            ]
+        }
+    `
+
+	actual := exportValueFromScript(t, script)
+
+	addressType := cadence.NewAddressType()
+	stringType := cadence.NewStringType()
+
+	expected := cadence.NewArray([]cadence.Value{
+		cadence.BytesToAddress([]byte{0x1}),
+		cadence.NewArray([]cadence.Value{
+			cadence.BytesToAddress([]byte{0x2}),
+		}).WithType(&cadence.VariableSizedArrayType{
+			ElementType: addressType,
+		}),
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key:   cadence.String("recipient"),
+				Value: cadence.BytesToAddress([]byte{0x3}),
+			},
+		}).WithType(&cadence.DictionaryType{
+			KeyType:     stringType,
+			ElementType: addressType,
+		}),
+	}).WithType(&cadence.VariableSizedArrayType{
+		ElementType: cadence.NewAnyStructType(),
+	})
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestExportStructValue(t *testing.T) {
 
 	t.Parallel()
@@ -2814,6 +2862,116 @@ func TestRuntimeComplexStructArgumentPassing(t *testing.T) {
 
 }
 
+func TestRuntimeNestedStructArgumentPassing(t *testing.T) {
+
+	t.Parallel()
+
+	structType := &cadence.StructType{
+		Location:            common.ScriptLocation{},
+		QualifiedIdentifier: "Foo",
+		Fields: []cadence.Field{
+			{
+				Identifier: "id",
+				Type:       cadence.IntType{},
+			},
+			{
+				Identifier: "tags",
+				Type: &cadence.VariableSizedArrayType{
+					ElementType: cadence.StringType{},
+				},
+			},
+		},
+	}
+
+	newStruct := func(id int, tags ...string) cadence.Struct {
+		tagValues := make([]cadence.Value, 0, len(tags))
+		for _, tag := range tags {
+			tagValues = append(tagValues, cadence.String(tag))
+		}
+
+		return cadence.NewStruct([]cadence.Value{
+			cadence.NewInt(id),
+			cadence.NewArray(tagValues).WithType(&cadence.VariableSizedArrayType{
+				ElementType: cadence.StringType{},
+			}),
+		}).WithType(structType)
+	}
+
+	// Compare the encodings, as exported types are not shared between values
+	assertEqualEncoding := func(t *testing.T, expected, actual cadence.Value) {
+		expectedEncoding, err := json.Encode(expected)
+		require.NoError(t, err)
+
+		actualEncoding, err := json.Encode(actual)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(expectedEncoding), string(actualEncoding))
+	}
+
+	const fooDeclaration = `
+        pub struct Foo {
+            pub let id: Int
+            pub let tags: [String]
+
+            init(id: Int, tags: [String]) {
+                self.id = id
+                self.tags = tags
+            }
+        }
+    `
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+
+		script := fooDeclaration + `
+            pub fun main(arg: [Foo]): [Foo] {
+                assert(arg[1].id == 2)
+                assert(arg[1].tags[0] == "b")
+                return arg
+            }
+        `
+
+		arg := cadence.NewArray([]cadence.Value{
+			newStruct(1, "a"),
+			newStruct(2, "b", "c"),
+		}).WithType(&cadence.VariableSizedArrayType{
+			ElementType: structType,
+		})
+
+		actual, err := executeTestScript(t, script, arg)
+		require.NoError(t, err)
+
+		assertEqualEncoding(t, arg, actual)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		script := fooDeclaration + `
+            pub fun main(arg: {String: Foo}): {String: Foo} {
+                assert(arg["x"]!.id == 1)
+                assert(arg["x"]!.tags[1] == "b")
+                return arg
+            }
+        `
+
+		arg := cadence.NewDictionary([]cadence.KeyValuePair{
+			{
+				Key:   cadence.String("x"),
+				Value: newStruct(1, "a", "b"),
+			},
+		}).WithType(&cadence.DictionaryType{
+			KeyType:     cadence.StringType{},
+			ElementType: structType,
+		})
+
+		actual, err := executeTestScript(t, script, arg)
+		require.NoError(t, err)
+
+		assertEqualEncoding(t, arg, actual)
+	})
+}
+
 func TestRuntimeComplexStructWithAnyStructFields(t *testing.T) {
 
 	t.Parallel()
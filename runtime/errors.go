@@ -159,6 +159,25 @@ func (e *InvalidEntryPointArgumentError) Error() string {
 	)
 }
 
+// MissingEntryPointArgumentError
+
+type MissingEntryPointArgumentError struct {
+	Name  string
+	Index int
+}
+
+var _ errors.UserError = &MissingEntryPointArgumentError{}
+
+func (*MissingEntryPointArgumentError) IsUserError() {}
+
+func (e *MissingEntryPointArgumentError) Error() string {
+	return fmt.Sprintf(
+		"missing argument for non-optional parameter `%s` at index %d",
+		e.Name,
+		e.Index,
+	)
+}
+
 // MalformedValueError
 
 type MalformedValueError struct {
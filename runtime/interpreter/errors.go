@@ -298,6 +298,59 @@ func (e UnderflowError) Error() string {
 	return "underflow"
 }
 
+// ConversionError is reported when a value cannot be converted to another type,
+// e.g. when an integer value is out of the range of the target integer type.
+// The underlying error is the cause, e.g. an OverflowError or an UnderflowError.
+type ConversionError struct {
+	Err        error
+	SourceType StaticType
+	TargetType StaticType
+	LocationRange
+}
+
+var _ errors.UserError = ConversionError{}
+
+func (ConversionError) IsUserError() {}
+
+func (e ConversionError) Error() string {
+	return fmt.Sprintf(
+		"cannot convert value of type `%s` to type `%s`: %s",
+		e.SourceType.String(),
+		e.TargetType.String(),
+		e.Err.Error(),
+	)
+}
+
+func (e ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func newConversionOverflowError(
+	sourceType StaticType,
+	targetType StaticType,
+	locationRange LocationRange,
+) error {
+	return ConversionError{
+		Err:           OverflowError{LocationRange: locationRange},
+		SourceType:    sourceType,
+		TargetType:    targetType,
+		LocationRange: locationRange,
+	}
+}
+
+func newConversionUnderflowError(
+	sourceType StaticType,
+	targetType StaticType,
+	locationRange LocationRange,
+) error {
+	return ConversionError{
+		Err:           UnderflowError{LocationRange: locationRange},
+		SourceType:    sourceType,
+		TargetType:    targetType,
+		LocationRange: locationRange,
+	}
+}
+
 // UnderflowError
 
 type DivisionByZeroError struct {
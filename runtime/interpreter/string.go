@@ -108,6 +108,76 @@ func stringFunctionFromCharacters(invocation Invocation) Value {
 	return NewUnmeteredStringValue(builder.String())
 }
 
+func stringFunctionJoin(invocation Invocation) Value {
+	stringsArray, ok := invocation.Arguments[0].(*ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	separator, ok := invocation.Arguments[1].(*StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+	locationRange := invocation.LocationRange
+
+	count := stringsArray.Count()
+	if count == 0 {
+		return emptyString
+	}
+
+	// Meter the length of the resulting string before building it
+
+	var length int
+	stringsArray.Iterate(inter, func(element Value) (resume bool) {
+		str, ok := element.(*StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+		length = safeAdd(length, len(str.Str), locationRange)
+		return true
+	})
+
+	length = safeAdd(
+		length,
+		safeMul(count-1, len(separator.Str), locationRange),
+		locationRange,
+	)
+
+	// Joining copies all parts and separators into the resulting string
+
+	inter.ReportComputation(
+		common.ComputationKindStringOperation,
+		uint(length+count),
+	)
+
+	memoryUsage := common.NewStringMemoryUsage(length)
+
+	return NewStringValue(
+		inter,
+		memoryUsage,
+		func() string {
+			var builder strings.Builder
+			builder.Grow(length)
+
+			first := true
+			stringsArray.Iterate(inter, func(element Value) (resume bool) {
+				if !first {
+					builder.WriteString(separator.Str)
+				}
+				first = false
+
+				builder.WriteString(element.(*StringValue).Str)
+
+				return true
+			})
+
+			return builder.String()
+		},
+	)
+}
+
 // stringFunction is the `String` function. It is stateless, hence it can be re-used across interpreters.
 var stringFunction = func() Value {
 	functionValue := NewUnmeteredHostFunctionValue(
@@ -150,5 +220,13 @@ var stringFunction = func() Value {
 		),
 	)
 
+	addMember(
+		sema.StringTypeJoinFunctionName,
+		NewUnmeteredHostFunctionValue(
+			sema.StringTypeJoinFunctionType,
+			stringFunctionJoin,
+		),
+	)
+
 	return functionValue
 }()
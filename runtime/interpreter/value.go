@@ -363,6 +363,30 @@ func (v TypeValue) GetMember(interpreter *Interpreter, _ LocationRange, name str
 		return NewStringValue(interpreter, memoryUsage, func() string {
 			return typeID
 		})
+	case sema.MetaTypeElementTypeFieldName:
+		var elementType StaticType
+		switch staticType := v.Type.(type) {
+		case ArrayStaticType:
+			elementType = staticType.ElementType()
+		case DictionaryStaticType:
+			elementType = staticType.ValueType
+		}
+		if elementType == nil {
+			return Nil
+		}
+		return NewSomeValueNonCopying(
+			interpreter,
+			NewTypeValue(interpreter, elementType),
+		)
+	case sema.MetaTypeKeyTypeFieldName:
+		dictionaryType, ok := v.Type.(DictionaryStaticType)
+		if !ok {
+			return Nil
+		}
+		return NewSomeValueNonCopying(
+			interpreter,
+			NewTypeValue(interpreter, dictionaryType.KeyType),
+		)
 	case "isSubtype":
 		return NewHostFunctionValue(
 			interpreter,
@@ -469,8 +493,13 @@ func (TypeValue) ChildStorables() []atree.Storable {
 // HashInput returns a byte slice containing:
 // - HashInputTypeType (1 byte)
 // - type id (n bytes)
+//
+// The type ID of an unknown type is empty.
 func (v TypeValue) HashInput(interpreter *Interpreter, _ LocationRange, scratch []byte) []byte {
-	typeID := interpreter.MustConvertStaticToSemaType(v.Type).ID()
+	var typeID sema.TypeID
+	if v.Type != nil {
+		typeID = interpreter.MustConvertStaticToSemaType(v.Type).ID()
+	}
 
 	length := 1 + len(typeID)
 	var buf []byte
@@ -1075,6 +1104,79 @@ func (v *StringValue) Slice(from IntValue, to IntValue, locationRange LocationRa
 	return NewUnmeteredStringValue(v.Str[start:end])
 }
 
+// Split returns the substrings of the string which are separated by the given separator.
+// If the separator is empty, the string is split into its characters (grapheme clusters).
+func (v *StringValue) Split(interpreter *Interpreter, _ LocationRange, separator *StringValue) *ArrayValue {
+
+	// Determine the number of parts before splitting,
+	// so the resulting array is metered before the parts are created
+
+	var count int
+	var nextPart func() (string, bool)
+
+	if separator.Str == "" {
+		count = v.Length()
+
+		v.prepareGraphemes()
+
+		nextPart = func() (string, bool) {
+			if !v.graphemes.Next() {
+				return "", false
+			}
+			return v.graphemes.Str(), true
+		}
+	} else {
+		count = strings.Count(v.Str, separator.Str) + 1
+
+		remaining := v.Str
+		done := false
+
+		nextPart = func() (string, bool) {
+			if done {
+				return "", false
+			}
+
+			part, rest, found := strings.Cut(remaining, separator.Str)
+			if !found {
+				done = true
+			}
+			remaining = rest
+
+			return part, true
+		}
+	}
+
+	// Splitting scans the whole string, and creates a string for each part
+
+	interpreter.ReportComputation(
+		common.ComputationKindStringOperation,
+		uint(len(v.Str)+count),
+	)
+
+	return NewArrayValueWithIterator(
+		interpreter,
+		VariableSizedStaticType{
+			Type: PrimitiveStaticTypeString,
+		},
+		common.ZeroAddress,
+		uint64(count),
+		func() Value {
+			part, ok := nextPart()
+			if !ok {
+				return nil
+			}
+
+			return NewStringValue(
+				interpreter,
+				common.NewStringMemoryUsage(len(part)),
+				func() string {
+					return part
+				},
+			)
+		},
+	)
+}
+
 func (v *StringValue) checkBounds(index int, locationRange LocationRange) {
 	length := v.Length()
 
@@ -1173,6 +1275,24 @@ func (v *StringValue) GetMember(interpreter *Interpreter, locationRange Location
 			},
 		)
 
+	case sema.StringTypeSplitFunctionName:
+		return NewHostFunctionValue(
+			interpreter,
+			sema.StringTypeSplitFunctionType,
+			func(invocation Invocation) Value {
+				separator, ok := invocation.Arguments[0].(*StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return v.Split(
+					invocation.Interpreter,
+					invocation.LocationRange,
+					separator,
+				)
+			},
+		)
+
 	case "toLower":
 		return NewHostFunctionValue(
 			interpreter,
@@ -2845,6 +2965,60 @@ type BigNumberValue interface {
 	ToBigInt(memoryGauge common.MemoryGauge) *big.Int
 }
 
+// numberValueStaticType returns the static type of the given number value.
+// Unlike Value.StaticType, it does not require an interpreter,
+// e.g. to report the source type of a failed conversion independent of the memory gauge.
+func numberValueStaticType(memoryGauge common.MemoryGauge, value Value) StaticType {
+	var staticType PrimitiveStaticType
+
+	switch value.(type) {
+	case IntValue:
+		staticType = PrimitiveStaticTypeInt
+	case Int8Value:
+		staticType = PrimitiveStaticTypeInt8
+	case Int16Value:
+		staticType = PrimitiveStaticTypeInt16
+	case Int32Value:
+		staticType = PrimitiveStaticTypeInt32
+	case Int64Value:
+		staticType = PrimitiveStaticTypeInt64
+	case Int128Value:
+		staticType = PrimitiveStaticTypeInt128
+	case Int256Value:
+		staticType = PrimitiveStaticTypeInt256
+	case UIntValue:
+		staticType = PrimitiveStaticTypeUInt
+	case UInt8Value:
+		staticType = PrimitiveStaticTypeUInt8
+	case UInt16Value:
+		staticType = PrimitiveStaticTypeUInt16
+	case UInt32Value:
+		staticType = PrimitiveStaticTypeUInt32
+	case UInt64Value:
+		staticType = PrimitiveStaticTypeUInt64
+	case UInt128Value:
+		staticType = PrimitiveStaticTypeUInt128
+	case UInt256Value:
+		staticType = PrimitiveStaticTypeUInt256
+	case Word8Value:
+		staticType = PrimitiveStaticTypeWord8
+	case Word16Value:
+		staticType = PrimitiveStaticTypeWord16
+	case Word32Value:
+		staticType = PrimitiveStaticTypeWord32
+	case Word64Value:
+		staticType = PrimitiveStaticTypeWord64
+	case Fix64Value:
+		staticType = PrimitiveStaticTypeFix64
+	case UFix64Value:
+		staticType = PrimitiveStaticTypeUFix64
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	return NewPrimitiveStaticType(memoryGauge, staticType)
+}
+
 // Int
 
 type IntValue struct {
@@ -3820,18 +3994,18 @@ func ConvertInt8(memoryGauge common.MemoryGauge, value Value, locationRange Loca
 		case BigNumberValue:
 			v := value.ToBigInt(memoryGauge)
 			if v.Cmp(sema.Int8TypeMaxInt) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt8, locationRange))
 			} else if v.Cmp(sema.Int8TypeMinInt) < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt8, locationRange))
 			}
 			return int8(v.Int64())
 
 		case NumberValue:
 			v := value.ToInt(locationRange)
 			if v > math.MaxInt8 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt8, locationRange))
 			} else if v < math.MinInt8 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt8, locationRange))
 			}
 			return int8(v)
 
@@ -4406,18 +4580,18 @@ func ConvertInt16(memoryGauge common.MemoryGauge, value Value, locationRange Loc
 		case BigNumberValue:
 			v := value.ToBigInt(memoryGauge)
 			if v.Cmp(sema.Int16TypeMaxInt) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt16, locationRange))
 			} else if v.Cmp(sema.Int16TypeMinInt) < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt16, locationRange))
 			}
 			return int16(v.Int64())
 
 		case NumberValue:
 			v := value.ToInt(locationRange)
 			if v > math.MaxInt16 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt16, locationRange))
 			} else if v < math.MinInt16 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt16, locationRange))
 			}
 			return int16(v)
 
@@ -4994,18 +5168,18 @@ func ConvertInt32(memoryGauge common.MemoryGauge, value Value, locationRange Loc
 		case BigNumberValue:
 			v := value.ToBigInt(memoryGauge)
 			if v.Cmp(sema.Int32TypeMaxInt) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt32, locationRange))
 			} else if v.Cmp(sema.Int32TypeMinInt) < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt32, locationRange))
 			}
 			return int32(v.Int64())
 
 		case NumberValue:
 			v := value.ToInt(locationRange)
 			if v > math.MaxInt32 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt32, locationRange))
 			} else if v < math.MinInt32 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt32, locationRange))
 			}
 			return int32(v)
 
@@ -5583,9 +5757,9 @@ func ConvertInt64(memoryGauge common.MemoryGauge, value Value, locationRange Loc
 		case BigNumberValue:
 			v := value.ToBigInt(memoryGauge)
 			if v.Cmp(sema.Int64TypeMaxInt) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt64, locationRange))
 			} else if v.Cmp(sema.Int64TypeMinInt) < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt64, locationRange))
 			}
 			return v.Int64()
 
@@ -6256,9 +6430,9 @@ func ConvertInt128(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 		}
 
 		if v.Cmp(sema.Int128TypeMaxIntBig) > 0 {
-			panic(OverflowError{LocationRange: locationRange})
+			panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt128, locationRange))
 		} else if v.Cmp(sema.Int128TypeMinIntBig) < 0 {
-			panic(UnderflowError{LocationRange: locationRange})
+			panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt128, locationRange))
 		}
 
 		return v
@@ -6942,9 +7116,9 @@ func ConvertInt256(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 		}
 
 		if v.Cmp(sema.Int256TypeMaxIntBig) > 0 {
-			panic(OverflowError{LocationRange: locationRange})
+			panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt256, locationRange))
 		} else if v.Cmp(sema.Int256TypeMinIntBig) < 0 {
-			panic(UnderflowError{LocationRange: locationRange})
+			panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeInt256, locationRange))
 		}
 
 		return v
@@ -7181,7 +7355,7 @@ func ConvertUInt(memoryGauge common.MemoryGauge, value Value, locationRange Loca
 			func() *big.Int {
 				v := value.ToBigInt(memoryGauge)
 				if v.Sign() < 0 {
-					panic(UnderflowError{LocationRange: locationRange})
+					panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt, locationRange))
 				}
 				return v
 			},
@@ -7190,7 +7364,7 @@ func ConvertUInt(memoryGauge common.MemoryGauge, value Value, locationRange Loca
 	case NumberValue:
 		v := value.ToInt(locationRange)
 		if v < 0 {
-			panic(UnderflowError{LocationRange: locationRange})
+			panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt, locationRange))
 		}
 		return NewUIntValueFromUint64(
 			memoryGauge,
@@ -8048,6 +8222,7 @@ func (v UInt8Value) HashInput(_ *Interpreter, _ LocationRange, scratch []byte) [
 func ConvertUnsigned[T Unsigned](
 	memoryGauge common.MemoryGauge,
 	value Value,
+	targetType StaticType,
 	maxBigNumber *big.Int,
 	maxNumber int,
 	locationRange LocationRange,
@@ -8056,18 +8231,18 @@ func ConvertUnsigned[T Unsigned](
 	case BigNumberValue:
 		v := value.ToBigInt(memoryGauge)
 		if v.Cmp(maxBigNumber) > 0 {
-			panic(OverflowError{LocationRange: locationRange})
+			panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), targetType, locationRange))
 		} else if v.Sign() < 0 {
-			panic(UnderflowError{LocationRange: locationRange})
+			panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), targetType, locationRange))
 		}
 		return T(v.Int64())
 
 	case NumberValue:
 		v := value.ToInt(locationRange)
 		if maxNumber > 0 && v > maxNumber {
-			panic(OverflowError{LocationRange: locationRange})
+			panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), targetType, locationRange))
 		} else if v < 0 {
-			panic(UnderflowError{LocationRange: locationRange})
+			panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), targetType, locationRange))
 		}
 		return T(v)
 
@@ -8100,6 +8275,7 @@ func ConvertUInt8(memoryGauge common.MemoryGauge, value Value, locationRange Loc
 			return ConvertUnsigned[uint8](
 				memoryGauge,
 				value,
+				PrimitiveStaticTypeUInt8,
 				sema.UInt8TypeMaxInt,
 				math.MaxUint8,
 				locationRange,
@@ -8605,6 +8781,7 @@ func ConvertUInt16(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 			return ConvertUnsigned[uint16](
 				memoryGauge,
 				value,
+				PrimitiveStaticTypeUInt16,
 				sema.UInt16TypeMaxInt,
 				math.MaxUint16,
 				locationRange,
@@ -9117,6 +9294,7 @@ func ConvertUInt32(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 			return ConvertUnsigned[uint32](
 				memoryGauge,
 				value,
+				PrimitiveStaticTypeUInt32,
 				sema.UInt32TypeMaxInt,
 				math.MaxUint32,
 				locationRange,
@@ -9656,6 +9834,7 @@ func ConvertUInt64(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 			return ConvertUnsigned[uint64](
 				memoryGauge,
 				value,
+				PrimitiveStaticTypeUInt64,
 				sema.UInt64TypeMaxInt,
 				-1,
 				locationRange,
@@ -10269,9 +10448,9 @@ func ConvertUInt128(memoryGauge common.MemoryGauge, value Value, locationRange L
 			}
 
 			if v.Cmp(sema.UInt128TypeMaxIntBig) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt128, locationRange))
 			} else if v.Sign() < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt128, locationRange))
 			}
 
 			return v
@@ -10901,9 +11080,9 @@ func ConvertUInt256(memoryGauge common.MemoryGauge, value Value, locationRange L
 			}
 
 			if v.Cmp(sema.UInt256TypeMaxIntBig) > 0 {
-				panic(OverflowError{LocationRange: locationRange})
+				panic(newConversionOverflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt256, locationRange))
 			} else if v.Sign() < 0 {
-				panic(UnderflowError{LocationRange: locationRange})
+				panic(newConversionUnderflowError(numberValueStaticType(memoryGauge, value), PrimitiveStaticTypeUInt256, locationRange))
 			}
 
 			return v
@@ -13756,9 +13935,113 @@ func ConvertUFix64(memoryGauge common.MemoryGauge, value Value, locationRange Lo
 }
 
 func (v UFix64Value) GetMember(interpreter *Interpreter, locationRange LocationRange, name string) Value {
+	switch name {
+	case sema.FixedPointTypeFloorFunctionName:
+		return NewHostFunctionValue(
+			interpreter,
+			sema.UFix64TypeFloorFunctionType,
+			func(invocation Invocation) Value {
+				return v.Floor(invocation.Interpreter)
+			},
+		)
+
+	case sema.FixedPointTypeCeilFunctionName:
+		return NewHostFunctionValue(
+			interpreter,
+			sema.UFix64TypeCeilFunctionType,
+			func(invocation Invocation) Value {
+				return v.Ceil(invocation.Interpreter, locationRange)
+			},
+		)
+
+	case sema.FixedPointTypeRoundFunctionName:
+		return NewHostFunctionValue(
+			interpreter,
+			sema.UFix64TypeRoundFunctionType,
+			func(invocation Invocation) Value {
+				places, ok := invocation.Arguments[0].(UInt8Value)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				return v.Round(invocation.Interpreter, uint8(places), locationRange)
+			},
+		)
+	}
+
 	return getNumberValueMember(interpreter, v, name, sema.UFix64Type, locationRange)
 }
 
+// Floor returns the greatest integer value less than or equal to v.
+func (v UFix64Value) Floor(interpreter *Interpreter) UFix64Value {
+	return NewUFix64Value(
+		interpreter,
+		func() uint64 {
+			return uint64(v) - uint64(v)%sema.Fix64Factor
+		},
+	)
+}
+
+// Ceil returns the smallest integer value greater than or equal to v.
+func (v UFix64Value) Ceil(interpreter *Interpreter, locationRange LocationRange) UFix64Value {
+	return v.roundToMultiple(
+		interpreter,
+		sema.Fix64Factor,
+		func(remainder uint64) bool {
+			return remainder > 0
+		},
+		locationRange,
+	)
+}
+
+// Round returns v rounded to the given number of decimal places,
+// rounding halfway values away from zero.
+func (v UFix64Value) Round(interpreter *Interpreter, places uint8, locationRange LocationRange) UFix64Value {
+	if uint(places) >= sema.Fix64Scale {
+		return v
+	}
+
+	multiple := uint64(1)
+	for i := uint(places); i < sema.Fix64Scale; i++ {
+		multiple *= 10
+	}
+
+	return v.roundToMultiple(
+		interpreter,
+		multiple,
+		func(remainder uint64) bool {
+			return remainder >= multiple-remainder
+		},
+		locationRange,
+	)
+}
+
+// roundToMultiple truncates v to the given multiple of the smallest representable value,
+// and rounds up to the next multiple if roundUp returns true for the truncated remainder.
+func (v UFix64Value) roundToMultiple(
+	interpreter *Interpreter,
+	multiple uint64,
+	roundUp func(remainder uint64) bool,
+	locationRange LocationRange,
+) UFix64Value {
+	return NewUFix64Value(
+		interpreter,
+		func() uint64 {
+			remainder := uint64(v) % multiple
+			truncated := uint64(v) - remainder
+
+			if !roundUp(remainder) {
+				return truncated
+			}
+
+			if truncated > math.MaxUint64-multiple {
+				panic(OverflowError{LocationRange: locationRange})
+			}
+
+			return truncated + multiple
+		},
+	)
+}
+
 func (UFix64Value) RemoveMember(_ *Interpreter, _ LocationRange, _ string) Value {
 	// Numbers have no removable members (fields / functions)
 	panic(errors.NewUnreachableError())
@@ -17686,7 +17969,19 @@ func NewAddressValueFromConstructor(
 
 func ConvertAddress(memoryGauge common.MemoryGauge, value Value, locationRange LocationRange) AddressValue {
 	converter := func() (result common.Address) {
-		uint64Value := ConvertUInt64(memoryGauge, value, locationRange)
+		uint64Value := NewUInt64Value(
+			memoryGauge,
+			func() uint64 {
+				return ConvertUnsigned[uint64](
+					memoryGauge,
+					value,
+					PrimitiveStaticTypeAddress,
+					sema.UInt64TypeMaxInt,
+					-1,
+					locationRange,
+				)
+			},
+		)
 
 		binary.BigEndian.PutUint64(
 			result[:common.AddressLength],
@@ -362,6 +362,7 @@ func validateArgumentParams(
 	locationRange interpreter.LocationRange,
 	arguments [][]byte,
 	parameters []sema.Parameter,
+	optionalArgumentsEnabled bool,
 ) (
 	[]interpreter.Value,
 	error,
@@ -369,18 +370,32 @@ func validateArgumentParams(
 	argumentCount := len(arguments)
 	parameterCount := len(parameters)
 
-	if argumentCount != parameterCount {
-		return nil, InvalidEntryPointParameterCountError{
-			Expected: parameterCount,
-			Actual:   argumentCount,
-		}
+	err := checkEntryPointArgumentCount(argumentCount, parameterCount, optionalArgumentsEnabled)
+	if err != nil {
+		return nil, err
 	}
 
-	argumentValues := make([]interpreter.Value, len(arguments))
+	argumentValues := make([]interpreter.Value, parameterCount)
 
 	// Decode arguments against parameter types
 	for parameterIndex, parameter := range parameters {
 		parameterType := parameter.TypeAnnotation.Type
+
+		// Omitted trailing arguments are only allowed for optional parameters,
+		// and default to nil
+
+		if parameterIndex >= argumentCount {
+			if _, ok := parameterType.(*sema.OptionalType); !ok {
+				return nil, &MissingEntryPointArgumentError{
+					Index: parameterIndex,
+					Name:  parameter.Identifier,
+				}
+			}
+
+			argumentValues[parameterIndex] = interpreter.Nil
+			continue
+		}
+
 		argument := arguments[parameterIndex]
 
 		exportedParameterType := ExportMeteredType(inter, parameterType, map[sema.TypeID]cadence.Type{})
@@ -479,6 +494,26 @@ func validateArgumentParams(
 	return argumentValues, nil
 }
 
+// checkEntryPointArgumentCount checks that the number of given arguments
+// matches the number of entry point parameters.
+// If optional arguments are enabled, trailing arguments may be omitted.
+func checkEntryPointArgumentCount(
+	argumentCount int,
+	parameterCount int,
+	optionalArgumentsEnabled bool,
+) error {
+	if argumentCount == parameterCount ||
+		(optionalArgumentsEnabled && argumentCount < parameterCount) {
+
+		return nil
+	}
+
+	return InvalidEntryPointParameterCountError{
+		Expected: parameterCount,
+		Actual:   argumentCount,
+	}
+}
+
 func hasValidStaticType(inter *interpreter.Interpreter, value interpreter.Value) bool {
 	switch value := value.(type) {
 	case *interpreter.ArrayValue:
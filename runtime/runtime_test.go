@@ -1413,6 +1413,109 @@ func TestRuntimeTransactionWithArguments(t *testing.T) {
 	}
 }
 
+func TestRuntimeTransactionWithOptionalArguments(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+      transaction(x: Int, y: String?) {
+        execute {
+          log(x)
+          log(y)
+        }
+      }
+    `
+
+	execute := func(args [][]byte, optionalArgumentsEnabled bool) ([]string, error) {
+		rt := newTestInterpreterRuntime()
+		rt.interpreterRuntime.defaultConfig.OptionalArgumentsEnabled = optionalArgumentsEnabled
+
+		var loggedMessages []string
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+			log: func(message string) {
+				loggedMessages = append(loggedMessages, message)
+			},
+			meterMemory: func(_ common.MemoryUsage) error {
+				return nil
+			},
+		}
+		runtimeInterface.decodeArgument = func(b []byte, t cadence.Type) (value cadence.Value, err error) {
+			return json.Decode(runtimeInterface, b)
+		}
+
+		err := rt.ExecuteTransaction(
+			Script{
+				Source:    []byte(script),
+				Arguments: args,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+
+		return loggedMessages, err
+	}
+
+	t.Run("all arguments", func(t *testing.T) {
+		t.Parallel()
+
+		logs, err := execute(
+			[][]byte{
+				jsoncdc.MustEncode(cadence.NewInt(42)),
+				jsoncdc.MustEncode(cadence.NewOptional(cadence.String("foo"))),
+			},
+			true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"42", `"foo"`}, logs)
+	})
+
+	t.Run("omitted optional argument", func(t *testing.T) {
+		t.Parallel()
+
+		logs, err := execute(
+			[][]byte{
+				jsoncdc.MustEncode(cadence.NewInt(42)),
+			},
+			true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"42", "nil"}, logs)
+	})
+
+	t.Run("omitted required argument", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := execute(nil, true)
+		RequireError(t, err)
+
+		var missingArgumentErr *MissingEntryPointArgumentError
+		require.ErrorAs(t, err, &missingArgumentErr)
+		assert.Equal(t, "x", missingArgumentErr.Name)
+		assert.Equal(t, 0, missingArgumentErr.Index)
+	})
+
+	t.Run("omitted optional argument, disabled", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := execute(
+			[][]byte{
+				jsoncdc.MustEncode(cadence.NewInt(42)),
+			},
+			false,
+		)
+		RequireError(t, err)
+
+		require.ErrorAs(t, err, &InvalidEntryPointParameterCountError{})
+	})
+}
+
 func TestRuntimeScriptArguments(t *testing.T) {
 
 	t.Parallel()
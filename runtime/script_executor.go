@@ -236,6 +236,7 @@ func (executor *interpreterScriptExecutor) scriptExecutionFunction() InterpretFu
 			interpreter.EmptyLocationRange,
 			executor.script.Arguments,
 			executor.functionEntryPointType.Parameters,
+			executor.runtime.defaultConfig.OptionalArgumentsEnabled,
 		)
 		if err != nil {
 			return nil, err
@@ -31,6 +31,16 @@ const metaTypeSubtypeDocString = `
 Returns true if this type is a subtype of the given type at run-time
 `
 
+const metaTypeElementTypeDocString = `
+The element type of an array type, or the value type of a dictionary type.
+Nil for all other types
+`
+
+const metaTypeKeyTypeDocString = `
+The key type of a dictionary type.
+Nil for all other types
+`
+
 const MetaTypeName = "Type"
 
 // MetaType represents the type of a type.
@@ -46,6 +56,10 @@ var MetaType = &SimpleType{
 	Importable:    true,
 }
 
+const MetaTypeElementTypeFieldName = "elementType"
+
+const MetaTypeKeyTypeFieldName = "keyType"
+
 var MetaTypeIsSubtypeFunctionType = &FunctionType{
 	Parameters: []Parameter{
 		{
@@ -74,6 +88,34 @@ func init() {
 					)
 				},
 			},
+			MetaTypeElementTypeFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						memoryGauge,
+						t,
+						identifier,
+						&OptionalType{
+							Type: MetaType,
+						},
+						metaTypeElementTypeDocString,
+					)
+				},
+			},
+			MetaTypeKeyTypeFieldName: {
+				Kind: common.DeclarationKindField,
+				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						memoryGauge,
+						t,
+						identifier,
+						&OptionalType{
+							Type: MetaType,
+						},
+						metaTypeKeyTypeDocString,
+					)
+				},
+			},
 			"isSubtype": {
 				Kind: common.DeclarationKindFunction,
 				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
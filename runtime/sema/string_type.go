@@ -39,6 +39,13 @@ const StringTypeFromCharactersFunctionDocString = `
 Returns a string from the given array of characters
 `
 
+const StringTypeJoinFunctionName = "join"
+const StringTypeJoinFunctionDocString = `
+Returns a string which contains the given strings, in order, separated by the given separator
+`
+
+const StringTypeSplitFunctionName = "split"
+
 // StringType represents the string type
 var StringType = &SimpleType{
 	Name:          "String",
@@ -123,6 +130,18 @@ func init() {
 					)
 				},
 			},
+			StringTypeSplitFunctionName: {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						memoryGauge,
+						t,
+						identifier,
+						StringTypeSplitFunctionType,
+						stringTypeSplitFunctionDocString,
+					)
+				},
+			},
 			"toLower": {
 				Kind: common.DeclarationKindField,
 				Resolve: func(memoryGauge common.MemoryGauge, identifier string, _ ast.Range, _ func(error)) *Member {
@@ -217,6 +236,28 @@ const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
 
+var StringTypeSplitFunctionType = &FunctionType{
+	Parameters: []Parameter{
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: StringType,
+		},
+	),
+}
+
+const stringTypeSplitFunctionDocString = `
+Returns the substrings of the string which are separated by the given separator, in order.
+
+If the separator is empty, the string is split into its characters.
+If the string does not contain the separator, the result only contains the string itself.
+It does not modify the original string
+`
+
 const stringFunctionDocString = "Creates an empty string"
 
 var StringFunctionType = func() *FunctionType {
@@ -267,6 +308,13 @@ var StringFunctionType = func() *FunctionType {
 		StringTypeFromCharactersFunctionDocString,
 	))
 
+	addMember(NewUnmeteredPublicFunctionMember(
+		functionType,
+		StringTypeJoinFunctionName,
+		StringTypeJoinFunctionType,
+		StringTypeJoinFunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
@@ -323,3 +371,22 @@ var StringTypeFromCharactersFunctionType = &FunctionType{
 		StringType,
 	),
 }
+
+var StringTypeJoinFunctionType = &FunctionType{
+	Parameters: []Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "strings",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: StringType,
+			}),
+		},
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
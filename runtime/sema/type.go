@@ -926,6 +926,94 @@ func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[st
 	}
 }
 
+const FixedPointTypeFloorFunctionName = "floor"
+const fixedPointTypeFloorFunctionDocString = `
+Returns the greatest integer value less than or equal to self.
+`
+
+const FixedPointTypeCeilFunctionName = "ceil"
+const fixedPointTypeCeilFunctionDocString = `
+Returns the smallest integer value greater than or equal to self.
+Fails if the result is greater than the maximum value of the type.
+`
+
+const FixedPointTypeRoundFunctionName = "round"
+const fixedPointTypeRoundFunctionDocString = `
+Returns self rounded to the given number of decimal places.
+Halfway values are rounded away from zero.
+Fails if the result is greater than the maximum value of the type.
+`
+
+// UFix64TypeFloorFunctionType is the type of the `floor` function of `UFix64`
+var UFix64TypeFloorFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		UFix64Type,
+	),
+}
+
+// UFix64TypeCeilFunctionType is the type of the `ceil` function of `UFix64`
+var UFix64TypeCeilFunctionType = &FunctionType{
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		UFix64Type,
+	),
+}
+
+// UFix64TypeRoundFunctionType is the type of the `round` function of `UFix64`
+var UFix64TypeRoundFunctionType = &FunctionType{
+	Parameters: []Parameter{
+		{
+			Identifier:     "places",
+			TypeAnnotation: NewTypeAnnotation(UInt8Type),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		UFix64Type,
+	),
+}
+
+func addRoundingFunctions(t *FixedPointNumericType, members map[string]MemberResolver) {
+
+	addRoundingFunction := func(name string, functionType *FunctionType, docString string) {
+		members[name] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(memoryGauge common.MemoryGauge, identifier string, targetRange ast.Range, report func(error)) *Member {
+				return NewPublicFunctionMember(
+					memoryGauge, t, name, functionType, docString)
+			},
+		}
+	}
+
+	var floorFunctionType, ceilFunctionType, roundFunctionType *FunctionType
+
+	switch t {
+	case UFix64Type:
+		floorFunctionType = UFix64TypeFloorFunctionType
+		ceilFunctionType = UFix64TypeCeilFunctionType
+		roundFunctionType = UFix64TypeRoundFunctionType
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	addRoundingFunction(
+		FixedPointTypeFloorFunctionName,
+		floorFunctionType,
+		fixedPointTypeFloorFunctionDocString,
+	)
+
+	addRoundingFunction(
+		FixedPointTypeCeilFunctionName,
+		ceilFunctionType,
+		fixedPointTypeCeilFunctionDocString,
+	)
+
+	addRoundingFunction(
+		FixedPointTypeRoundFunctionName,
+		roundFunctionType,
+		fixedPointTypeRoundFunctionDocString,
+	)
+}
+
 // NumericType represent all the types in the integer range
 // and non-fractional ranged types.
 type NumericType struct {
@@ -1113,6 +1201,7 @@ type FixedPointNumericType struct {
 	supportsSaturatingDivide   bool
 	supportsSaturatingMultiply bool
 	supportsSaturatingSubtract bool
+	supportsRounding           bool
 	isSuperType                bool
 }
 
@@ -1193,6 +1282,15 @@ func (t *FixedPointNumericType) SupportsSaturatingDivide() bool {
 	return t.supportsSaturatingDivide
 }
 
+func (t *FixedPointNumericType) WithRounding() *FixedPointNumericType {
+	t.supportsRounding = true
+	return t
+}
+
+func (t *FixedPointNumericType) SupportsRounding() bool {
+	return t.supportsRounding
+}
+
 func (*FixedPointNumericType) IsType() {}
 
 func (t *FixedPointNumericType) String() string {
@@ -1289,6 +1387,10 @@ func (t *FixedPointNumericType) initializeMemberResolvers() {
 
 		addSaturatingArithmeticFunctions(t, members)
 
+		if t.SupportsRounding() {
+			addRoundingFunctions(t, members)
+		}
+
 		t.memberResolvers = withBuiltinMembers(t, members)
 	})
 }
@@ -1499,7 +1601,8 @@ var (
 			WithScale(Fix64Scale).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithRounding()
 )
 
 // Numeric type ranges
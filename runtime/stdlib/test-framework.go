@@ -22,6 +22,7 @@ package stdlib
 import (
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 // TestFramework is the interface to be implemented by the test providers.
@@ -59,13 +60,233 @@ type TestFramework interface {
 	StandardLibraryHandler() StandardLibraryHandler
 }
 
+// The following interfaces are optional extensions of the TestFramework interface.
+// Test providers implement them to support the corresponding functions of the Test contract.
+// Calling a function which requires an extension the test provider does not implement
+// fails with an UnsupportedTestFrameworkFeatureError.
+
+// ScriptReturnTypeTestFramework supports `Blockchain.scriptReturnType`.
+type ScriptReturnTypeTestFramework interface {
+	ScriptReturnType(inter *interpreter.Interpreter, code string) (sema.Type, error)
+}
+
+// NextAddressTestFramework supports `Blockchain.nextAddress`.
+type NextAddressTestFramework interface {
+	NextAddress() common.Address
+}
+
+// SequenceNumberTestFramework supports `Blockchain.sequenceNumber`.
+type SequenceNumberTestFramework interface {
+	SequenceNumber(address common.Address, keyIndex int) (uint64, error)
+}
+
+// TransactionsInBlockTestFramework supports `Blockchain.transactionsInBlock`.
+type TransactionsInBlockTestFramework interface {
+	TransactionsInBlock(height uint64) ([]*TransactionResult, error)
+}
+
+// GetCapabilityTestFramework supports `Blockchain.getCapability`.
+type GetCapabilityTestFramework interface {
+	GetCapability(address common.Address, path interpreter.PathValue) (*interpreter.StorageCapabilityValue, error)
+}
+
+// AllContractsTestFramework supports `Blockchain.allContracts`.
+type AllContractsTestFramework interface {
+	AllContracts(includeSystemContracts bool) (map[common.Address][]string, error)
+}
+
+// RunScriptAtHeightTestFramework supports `Blockchain.executeScriptAtHeight`.
+type RunScriptAtHeightTestFramework interface {
+	RunScriptAtHeight(
+		inter *interpreter.Interpreter,
+		code string,
+		arguments []interpreter.Value,
+		height uint64,
+	) *ScriptResult
+}
+
+// CheckContractTestFramework supports `Blockchain.checkContract`.
+type CheckContractTestFramework interface {
+	CheckContract(name string, code string) []error
+}
+
+// EventsTestFramework supports `Blockchain.events`, `Blockchain.recentEvents`,
+// and `Blockchain.clearEvents`.
+type EventsTestFramework interface {
+	Events(inter *interpreter.Interpreter) []interpreter.Value
+
+	RecentEvents(inter *interpreter.Interpreter) []interpreter.Value
+
+	ClearEvents()
+}
+
+// ContractCodeTestFramework supports `Blockchain.contractCodeHash`, `Blockchain.getContractCode`,
+// and `Blockchain.contractValue`.
+type ContractCodeTestFramework interface {
+	ContractCode(address common.Address, name string) (code []byte, found bool, err error)
+}
+
+// AccountKeysTestFramework supports `Blockchain.accountKeys`.
+type AccountKeysTestFramework interface {
+	AccountKeys(address common.Address) ([]*AccountKey, error)
+}
+
+// FlowTotalSupplyTestFramework supports `Blockchain.flowTotalSupply`.
+type FlowTotalSupplyTestFramework interface {
+	FlowTotalSupply() (uint64, error)
+}
+
+// GetBlockTestFramework supports `Blockchain.getBlock`.
+type GetBlockTestFramework interface {
+	GetBlock(height uint64) (*CommittedBlock, error)
+}
+
+// SetTransactionsPerBlockTestFramework supports `Blockchain.setTransactionsPerBlock`.
+type SetTransactionsPerBlockTestFramework interface {
+	SetTransactionsPerBlock(count int) error
+}
+
+// AccountExistsTestFramework supports `Blockchain.accountExists`.
+type AccountExistsTestFramework interface {
+	AccountExists(address common.Address) (bool, error)
+}
+
+// StateCommitmentTestFramework supports `Test.expectNoStateChange`.
+type StateCommitmentTestFramework interface {
+	StateCommitment() ([]byte, error)
+}
+
+// GoldenFileTestFramework supports `Test.expectJSON`.
+type GoldenFileTestFramework interface {
+	EncodeJSON(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error)
+
+	UpdateGoldenFile(path string, content []byte) (bool, error)
+}
+
+// DeploymentResultTestFramework supports reporting the computation used by a deployment
+// in the result of `Blockchain.deployContractWithResult`.
+// Without it, the deployment is performed using TestFramework.DeployContract,
+// and the computation used is reported as zero.
+type DeploymentResultTestFramework interface {
+	DeployContractWithResult(
+		inter *interpreter.Interpreter,
+		name string,
+		code string,
+		account *Account,
+		arguments []interpreter.Value,
+	) *DeploymentResult
+}
+
+// CallContractFunctionTestFramework supports `Blockchain.callContractFunction`.
+type CallContractFunctionTestFramework interface {
+	CallContractFunction(
+		inter *interpreter.Interpreter,
+		address common.Address,
+		name string,
+		function string,
+		arguments []interpreter.Value,
+	) *ScriptResult
+}
+
+// TransactionExpiryTestFramework supports transactions with a reference block and an expiry.
+// Transactions without a reference block and expiry are added using TestFramework.AddTransaction.
+type TransactionExpiryTestFramework interface {
+	AddTransactionWithExpiry(
+		inter *interpreter.Interpreter,
+		code string,
+		authorizers []common.Address,
+		signers []*Account,
+		arguments []interpreter.Value,
+		expiry *TransactionExpiry,
+	) error
+}
+
+// SpyTestFramework supports `Blockchain.spyOn` and `Test.expectCalled`.
+type SpyTestFramework interface {
+	SpyOn(address common.Address, contractName string, functionName string) error
+
+	FunctionCalls(
+		address common.Address,
+		contractName string,
+		functionName string,
+	) ([][]interpreter.Value, error)
+}
+
+// CheckTransactionTestFramework supports `Blockchain.checkTransaction`.
+type CheckTransactionTestFramework interface {
+	CheckTransaction(code string) []error
+}
+
+// PendingTransactionsTestFramework supports the functions of `Blockchain`
+// which fail if transactions of the current block were not executed yet,
+// e.g. `Blockchain.executeAndCommit`.
+type PendingTransactionsTestFramework interface {
+	PendingTransactionCount() int
+}
+
 type ScriptResult struct {
 	Value interpreter.Value
 	Error error
+	// Events are the events emitted by the script, in emission order,
+	// as values of their concrete event types
+	Events []interpreter.Value
+}
+
+type DeploymentResult struct {
+	Error error
+	// ComputationUsed is the computation used by the deployment
+	ComputationUsed uint64
 }
 
 type TransactionResult struct {
 	Error error
+	// WrittenPaths are the storage paths written by the transaction
+	WrittenPaths []interpreter.PathValue
+	// ReadPaths are the storage paths read by the transaction
+	ReadPaths []interpreter.PathValue
+	// Events are the events emitted by the transaction, in emission order,
+	// as values of their concrete event types
+	Events []interpreter.Value
+	// DestroyedResources is the number of resources destroyed by the transaction
+	DestroyedResources int
+	// ExecutionStatus is how far the transaction progressed on the blockchain
+	ExecutionStatus ExecutionStatus
+	// ComputationBreakdown is the computation used by the transaction, per computation kind
+	ComputationBreakdown map[common.ComputationKind]uint64
+	// TransactionIndex is the index of the transaction in its block, in execution order
+	TransactionIndex int
+	// AuthorizerMismatch is true if the transaction failed,
+	// because the number of its authorizers does not match
+	// the number of parameters of its prepare block
+	AuthorizerMismatch bool
+}
+
+// TransactionExpiry is the reference block and the expiry of a transaction.
+// A transaction is expired, and not executed, if the height of the current block
+// is past the reference block height plus the expiry.
+type TransactionExpiry struct {
+	ReferenceBlockHeight uint64
+	Expiry               uint64
+}
+
+// ExecutionStatus indicates how far a transaction progressed on the blockchain
+type ExecutionStatus uint8
+
+const (
+	// ExecutionStatusExecuted indicates the transaction was executed,
+	// but its block is not yet committed
+	ExecutionStatusExecuted ExecutionStatus = iota
+	// ExecutionStatusSealed indicates the transaction was executed,
+	// and its block is committed
+	ExecutionStatusSealed
+	// ExecutionStatusExpired indicates the transaction was not executed,
+	// because its reference block is past the expiry window
+	ExecutionStatusExpired
+)
+
+type CommittedBlock struct {
+	Block
+	TransactionCount int
 }
 
 type Account struct {
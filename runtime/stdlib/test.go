@@ -19,7 +19,11 @@
 package stdlib
 
 import (
+	"bytes"
+	goErrors "errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -42,19 +46,40 @@ const transactionResultTypeName = "TransactionResult"
 const resultStatusTypeName = "ResultStatus"
 const accountTypeName = "Account"
 const errorTypeName = "Error"
+const errorKindTypeName = "ErrorKind"
+const executionStatusTypeName = "ExecutionStatus"
 const matcherTypeName = "Matcher"
+const committedBlockTypeName = "CommittedBlock"
+const deploymentResultTypeName = "DeploymentResult"
 
 const succeededCaseName = "succeeded"
 const failedCaseName = "failed"
 
+const executedExecutionStatusCaseName = "executed"
+const sealedExecutionStatusCaseName = "sealed"
+const expiredExecutionStatusCaseName = "expired"
+
+const genericErrorKindCaseName = "generic"
+const arithmeticErrorKindCaseName = "arithmetic"
+const conversionErrorKindCaseName = "conversion"
+const authorizerMismatchErrorKindCaseName = "authorizerMismatch"
+const postConditionErrorKindCaseName = "postCondition"
+
 const transactionCodeFieldName = "code"
 const transactionAuthorizerFieldName = "authorizers"
 const transactionSignersFieldName = "signers"
 const transactionArgsFieldName = "arguments"
+const transactionReferenceBlockHeightFieldName = "referenceBlockHeight"
+const transactionExpiryFieldName = "expiry"
 
 const accountAddressFieldName = "address"
 
+const errorSetDetailsFunctionName = "setDetails"
+
+const transactionResultEventsFieldName = "events"
+
 const matcherTestFunctionName = "test"
+const matcherFailureMessageFieldName = "failureMessage"
 
 const addressesFieldName = "addresses"
 
@@ -120,11 +145,23 @@ func NewTestContract(
 	compositeValue.Functions[testExpectFunctionName] = testExpectFunction
 	compositeValue.Functions[testNewEmulatorBlockchainFunctionName] = testNewEmulatorBlockchainFunction(testFramework)
 	compositeValue.Functions[testReadFileFunctionName] = testReadFileFunction(testFramework)
+	compositeValue.Functions[testExpectJSONFunctionName] = testExpectJSONFunction(testFramework)
+	compositeValue.Functions[testPathFromStringFunctionName] = testPathFromStringFunction
+	compositeValue.Functions[testExpectNoStateChangeFunctionName] = testExpectNoStateChangeFunction(testFramework)
 
 	// Inject natively implemented matchers
 	compositeValue.Functions[newMatcherFunctionName] = newMatcherFunction
 	compositeValue.Functions[equalMatcherFunctionName] = equalMatcherFunction
 
+	compositeValue.Functions[beCloseToMatcherFunctionName] = beCloseToMatcherFunction
+	compositeValue.Functions[haveBorrowTypeMatcherFunctionName] = haveBorrowTypeMatcherFunction
+	compositeValue.Functions[testExpectEventOrderFunctionName] = testExpectEventOrderFunction
+	compositeValue.Functions[beSomeMatcherFunctionName] = beSomeMatcherFunction
+	compositeValue.Functions[testUnwrapFunctionName] = testUnwrapFunction
+	compositeValue.Functions[equalDictionaryMatcherFunctionName] = equalDictionaryMatcherFunction
+	compositeValue.Functions[haveLengthMatcherFunctionName] = haveLengthMatcherFunction
+	compositeValue.Functions[beInRangeMatcherFunctionName] = beInRangeMatcherFunction
+	compositeValue.Functions[beInExclusiveRangeMatcherFunctionName] = beInExclusiveRangeMatcherFunction
 	return compositeValue, nil
 }
 
@@ -188,6 +225,65 @@ var matcherType = func() *sema.CompositeType {
 
 var matcherTestFunctionType = compositeFunctionType(matcherType, matcherTestFunctionName)
 
+var matcherFailureMessageFunctionType = func() *sema.FunctionType {
+	member, ok := matcherType.Members.Get(matcherFailureMessageFieldName)
+	if !ok {
+		panic(memberNotFoundError(matcherTypeName, matcherFailureMessageFieldName))
+	}
+
+	optionalType, ok := member.TypeAnnotation.Type.(*sema.OptionalType)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected optional type",
+			matcherFailureMessageFieldName,
+		))
+	}
+
+	functionType, ok := optionalType.Type.(*sema.FunctionType)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected function type",
+			matcherFailureMessageFieldName,
+		))
+	}
+
+	return functionType
+}()
+
+var transactionResultType = func() *sema.CompositeType {
+	typ, ok := testContractType.NestedTypes.Get(transactionResultTypeName)
+	if !ok {
+		panic(typeNotFoundError(testContractTypeName, transactionResultTypeName))
+	}
+
+	compositeType, ok := typ.(*sema.CompositeType)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected struct type",
+			transactionResultTypeName,
+		))
+	}
+
+	return compositeType
+}()
+
+var errorType = func() *sema.CompositeType {
+	typ, ok := testContractType.NestedTypes.Get(errorTypeName)
+	if !ok {
+		panic(typeNotFoundError(testContractTypeName, errorTypeName))
+	}
+
+	compositeType, ok := typ.(*sema.CompositeType)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected struct type",
+			errorTypeName,
+		))
+	}
+
+	return compositeType
+}()
+
 func compositeFunctionType(parent *sema.CompositeType, funcName string) *sema.FunctionType {
 	testFunc, ok := parent.Members.Get(funcName)
 	if !ok {
@@ -288,6 +384,96 @@ func init() {
 		),
 	)
 
+	testContractType.Members.Set(
+		beCloseToMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			beCloseToMatcherFunctionName,
+			beCloseToMatcherFunctionType,
+			beCloseToMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		haveBorrowTypeMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			haveBorrowTypeMatcherFunctionName,
+			haveBorrowTypeMatcherFunctionType,
+			haveBorrowTypeMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testExpectEventOrderFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testExpectEventOrderFunctionName,
+			testExpectEventOrderFunctionType,
+			testExpectEventOrderFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		beSomeMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			beSomeMatcherFunctionName,
+			beSomeMatcherFunctionType,
+			beSomeMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		testUnwrapFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testUnwrapFunctionName,
+			testUnwrapFunctionType,
+			testUnwrapFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		equalDictionaryMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			equalDictionaryMatcherFunctionName,
+			equalDictionaryMatcherFunctionType,
+			equalDictionaryMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		haveLengthMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			haveLengthMatcherFunctionName,
+			haveLengthMatcherFunctionType,
+			haveLengthMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		beInRangeMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			beInRangeMatcherFunctionName,
+			beInRangeMatcherFunctionType,
+			beInRangeMatcherFunctionDocString,
+		),
+	)
+
+	testContractType.Members.Set(
+		beInExclusiveRangeMatcherFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			beInExclusiveRangeMatcherFunctionName,
+			beInExclusiveRangeMatcherFunctionType,
+			beInExclusiveRangeMatcherFunctionDocString,
+		),
+	)
+
 	// Test.readFile()
 	testContractType.Members.Set(
 		testReadFileFunctionName,
@@ -299,6 +485,39 @@ func init() {
 		),
 	)
 
+	// Test.expectJSON()
+	testContractType.Members.Set(
+		testExpectJSONFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testExpectJSONFunctionName,
+			testExpectJSONFunctionType,
+			testExpectJSONFunctionDocString,
+		),
+	)
+
+	// Test.pathFromString()
+	testContractType.Members.Set(
+		testPathFromStringFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testPathFromStringFunctionName,
+			testPathFromStringFunctionType,
+			testPathFromStringFunctionDocString,
+		),
+	)
+
+	// Test.expectNoStateChange()
+	testContractType.Members.Set(
+		testExpectNoStateChangeFunctionName,
+		sema.NewUnmeteredPublicFunctionMember(
+			testContractType,
+			testExpectNoStateChangeFunctionName,
+			testExpectNoStateChangeFunctionType,
+			testExpectNoStateChangeFunctionDocString,
+		),
+	)
+
 	// Enrich 'Test' contract elaboration with natively implemented composite types.
 	// e.g: 'EmulatorBackend' type.
 	TestContractChecker.Elaboration.SetCompositeType(
@@ -481,13 +700,65 @@ var testExpectFunction = interpreter.NewUnmeteredHostFunctionValue(
 		)
 
 		if !result {
-			panic(AssertionError{})
+			panic(AssertionError{
+				Message: matcherFailureMessage(
+					inter,
+					matcher,
+					value,
+					locationRange,
+				),
+			})
 		}
 
 		return interpreter.Void
 	},
 )
 
+// matcherFailureMessage returns the message of the given matcher
+// describing why the given value is not a match,
+// or an empty message if the matcher has no failure message.
+func matcherFailureMessage(
+	inter *interpreter.Interpreter,
+	matcher *interpreter.CompositeValue,
+	value interpreter.Value,
+	locationRange interpreter.LocationRange,
+) string {
+	failureMessage, ok := matcher.GetMember(
+		inter,
+		locationRange,
+		matcherFailureMessageFieldName,
+	).(*interpreter.SomeValue)
+	if !ok {
+		return ""
+	}
+
+	funcValue, ok := failureMessage.InnerValue(inter, locationRange).(interpreter.FunctionValue)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected function",
+			matcherFailureMessageFieldName,
+		))
+	}
+
+	message, err := inter.InvokeExternally(
+		funcValue,
+		funcValue.FunctionType(),
+		[]interpreter.Value{
+			value,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	messageValue, ok := message.(*interpreter.StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return messageValue.Str
+}
+
 func invokeMatcherTest(
 	inter *interpreter.Interpreter,
 	matcher interpreter.MemberAccessibleValue,
@@ -530,6 +801,159 @@ func invokeMatcherTest(
 	return bool(result)
 }
 
+// 'Test.expectEventOrder' function
+
+const testExpectEventOrderFunctionDocString = `
+Fails the test-case if the events of the given types were not emitted by the transaction
+in the given order. Events of other types are ignored.
+`
+
+const testExpectEventOrderFunctionName = "expectEventOrder"
+
+var testExpectEventOrderFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "result",
+			TypeAnnotation: sema.NewTypeAnnotation(transactionResultType),
+		},
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "types",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.VariableSizedType{
+					Type: sema.MetaType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+var testExpectEventOrderFunction = interpreter.NewUnmeteredHostFunctionValue(
+	testExpectEventOrderFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		result, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		events, ok := result.GetMember(
+			inter,
+			locationRange,
+			transactionResultEventsFieldName,
+		).(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		types, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		// Walk the events in emission order,
+		// and advance to the next expected type on every match
+
+		var index int
+		count := types.Count()
+
+		events.Iterate(inter, func(event interpreter.Value) (resume bool) {
+			if index >= count {
+				return false
+			}
+
+			expectedType, ok := types.Get(inter, locationRange, index).(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Unknown types never match
+			if expectedType.Type != nil &&
+				event.StaticType(inter).Equal(expectedType.Type) {
+
+				index++
+			}
+
+			return true
+		})
+
+		if index < count {
+			missingType, ok := types.Get(inter, locationRange, index).(interpreter.TypeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			var message string
+			if missingType.Type == nil {
+				message = "event of unknown type was not emitted in the expected order"
+			} else {
+				message = fmt.Sprintf(
+					"event of type %s was not emitted in the expected order",
+					inter.MustConvertStaticToSemaType(missingType.Type).QualifiedString(),
+				)
+			}
+
+			panic(AssertionError{
+				Message:       message,
+				LocationRange: locationRange,
+			})
+		}
+
+		return interpreter.Void
+	},
+)
+
+// 'Test.unwrap' function
+
+const testUnwrapFunctionDocString = `
+Returns the value of the given optional.
+Fails the test-case if the optional is nil.
+`
+
+const testUnwrapFunctionName = "unwrap"
+
+var testUnwrapFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "value",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: sema.AnyStructType,
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.AnyStructType,
+	),
+}
+
+var testUnwrapFunction = interpreter.NewUnmeteredHostFunctionValue(
+	testUnwrapFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		switch value := invocation.Arguments[0].(type) {
+		case *interpreter.SomeValue:
+			return value.InnerValue(invocation.Interpreter, invocation.LocationRange)
+
+		case interpreter.NilValue:
+			panic(AssertionError{
+				Message:       "expected a value, but got nil",
+				LocationRange: invocation.LocationRange,
+			})
+
+		default:
+			panic(errors.NewUnreachableError())
+		}
+	},
+)
+
 // 'Test.readFile' function
 
 const testReadFileFunctionDocString = `
@@ -572,19 +996,242 @@ func testReadFileFunction(testFramework TestFramework) *interpreter.HostFunction
 	)
 }
 
-// 'Test.newEmulatorBlockchain' function
+// 'Test.expectNoStateChange' function
 
-const testNewEmulatorBlockchainFunctionDocString = `
-Creates a blockchain which is backed by a new emulator instance.
+const testExpectNoStateChangeFunctionDocString = `
+Invokes the given function, and fails if the state of the blockchain changed,
+e.g. to assert that a failed transaction did not partially update storage.
 `
 
-const testNewEmulatorBlockchainFunctionName = "newEmulatorBlockchain"
-
-var testNewEmulatorBlockchainFunctionType = &sema.FunctionType{
-	ReturnTypeAnnotation: sema.NewTypeAnnotation(
-		blockchainType,
-	),
-}
+const testExpectNoStateChangeFunctionName = "expectNoStateChange"
+
+var testExpectNoStateChangeFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "function",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.VoidType),
+				},
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func testExpectNoStateChangeFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		testExpectNoStateChangeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			function, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[StateCommitmentTestFramework](
+				testFramework,
+				testExpectNoStateChangeFunctionName,
+			)
+
+			before, err := framework.StateCommitment()
+			if err != nil {
+				panic(err)
+			}
+
+			_, err = invocation.Interpreter.InvokeExternally(
+				function,
+				function.FunctionType(),
+				nil,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			after, err := framework.StateCommitment()
+			if err != nil {
+				panic(err)
+			}
+
+			if !bytes.Equal(before, after) {
+				panic(AssertionError{
+					Message:       "state of the blockchain changed",
+					LocationRange: invocation.LocationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.pathFromString' function
+
+const testPathFromStringFunctionDocString = `
+Parses the given string, e.g. "/storage/foo", into a path.
+Returns nil if the string is not a valid path.
+`
+
+const testPathFromStringFunctionName = "pathFromString"
+
+var testPathFromStringFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "path",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.OptionalType{
+			Type: sema.PathType,
+		},
+	),
+}
+
+var testPathFromStringFunction = interpreter.NewUnmeteredHostFunctionValue(
+	testPathFromStringFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		pathString, ok := invocation.Arguments[0].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		// A path has the form '/<domain>/<identifier>'
+
+		if !strings.HasPrefix(pathString.Str, "/") {
+			return interpreter.Nil
+		}
+
+		domainString, identifier, ok := strings.Cut(pathString.Str[1:], "/")
+		if !ok {
+			return interpreter.Nil
+		}
+
+		inter := invocation.Interpreter
+		identifierValue := interpreter.NewUnmeteredStringValue(identifier)
+
+		// The conversion functions check that the identifier is valid
+
+		switch common.PathDomainFromIdentifier(domainString) {
+		case common.PathDomainStorage:
+			return interpreter.ConvertStoragePath(inter, identifierValue)
+		case common.PathDomainPrivate:
+			return interpreter.ConvertPrivatePath(inter, identifierValue)
+		case common.PathDomainPublic:
+			return interpreter.ConvertPublicPath(inter, identifierValue)
+		default:
+			return interpreter.Nil
+		}
+	},
+)
+
+// 'Test.expectJSON' function
+
+const testExpectJSONFunctionDocString = `
+Fails if the JSON-CDC encoding of the given value does not match the content
+of the golden file at the given path.
+If the test framework updates golden files, the golden file is overwritten instead.
+`
+
+const testExpectJSONFunctionName = "expectJSON"
+
+var testExpectJSONFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "value",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.OptionalType{
+					Type: sema.AnyStructType,
+				},
+			),
+		},
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "goldenPath",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				sema.StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.VoidType,
+	),
+}
+
+func testExpectJSONFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		testExpectJSONFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			value := invocation.Arguments[0]
+
+			goldenPath, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[GoldenFileTestFramework](
+				testFramework,
+				testExpectJSONFunctionName,
+			)
+
+			actual, err := framework.EncodeJSON(invocation.Interpreter, value)
+			if err != nil {
+				panic(err)
+			}
+
+			updated, err := framework.UpdateGoldenFile(goldenPath.Str, actual)
+			if err != nil {
+				panic(err)
+			}
+			if updated {
+				return interpreter.Void
+			}
+
+			expected, err := testFramework.ReadFile(goldenPath.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			// Ignore surrounding whitespace, e.g. a trailing newline in the golden file
+			actual = bytes.TrimSpace(actual)
+			expectedBytes := bytes.TrimSpace([]byte(expected))
+
+			if !bytes.Equal(actual, expectedBytes) {
+				panic(AssertionError{
+					Message: fmt.Sprintf(
+						"value does not match golden file %s: expected %s, got %s",
+						goldenPath.Str,
+						expectedBytes,
+						actual,
+					),
+					LocationRange: invocation.LocationRange,
+				})
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'Test.newEmulatorBlockchain' function
+
+const testNewEmulatorBlockchainFunctionDocString = `
+Creates a blockchain which is backed by a new emulator instance.
+`
+
+const testNewEmulatorBlockchainFunctionName = "newEmulatorBlockchain"
+
+var testNewEmulatorBlockchainFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		blockchainType,
+	),
+}
 
 func testNewEmulatorBlockchainFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
 	return interpreter.NewUnmeteredHostFunctionValue(
@@ -736,6 +1383,12 @@ var EmulatorBackendType = func() *sema.CompositeType {
 			emulatorBackendExecuteScriptFunctionType,
 			emulatorBackendExecuteScriptFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendScriptReturnTypeFunctionName,
+			emulatorBackendScriptReturnTypeFunctionType,
+			emulatorBackendScriptReturnTypeFunctionDocString,
+		),
 		sema.NewUnmeteredPublicFunctionMember(
 			ty,
 			emulatorBackendCreateAccountFunctionName,
@@ -766,12 +1419,162 @@ var EmulatorBackendType = func() *sema.CompositeType {
 			emulatorBackendDeployContractFunctionType,
 			emulatorBackendDeployContractFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendDeployContractWithResultFunctionName,
+			emulatorBackendDeployContractWithResultFunctionType,
+			emulatorBackendDeployContractWithResultFunctionDocString,
+		),
 		sema.NewUnmeteredPublicFunctionMember(
 			ty,
 			emulatorBackendUseConfigFunctionName,
 			emulatorBackendUseConfigFunctionType,
 			emulatorBackendUseConfigFunctionDocString,
 		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendNextAddressFunctionName,
+			emulatorBackendNextAddressFunctionType,
+			emulatorBackendNextAddressFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendSequenceNumberFunctionName,
+			emulatorBackendSequenceNumberFunctionType,
+			emulatorBackendSequenceNumberFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendTransactionsInBlockFunctionName,
+			emulatorBackendTransactionsInBlockFunctionType,
+			emulatorBackendTransactionsInBlockFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendGetCapabilityFunctionName,
+			emulatorBackendGetCapabilityFunctionType,
+			emulatorBackendGetCapabilityFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendAllContractsFunctionName,
+			emulatorBackendAllContractsFunctionType,
+			emulatorBackendAllContractsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendExecuteScriptAtHeightFunctionName,
+			emulatorBackendExecuteScriptAtHeightFunctionType,
+			emulatorBackendExecuteScriptAtHeightFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendCheckContractFunctionName,
+			emulatorBackendCheckContractFunctionType,
+			emulatorBackendCheckContractFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendEventsFunctionName,
+			emulatorBackendEventsFunctionType,
+			emulatorBackendEventsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendRecentEventsFunctionName,
+			emulatorBackendRecentEventsFunctionType,
+			emulatorBackendRecentEventsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendClearEventsFunctionName,
+			emulatorBackendClearEventsFunctionType,
+			emulatorBackendClearEventsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendContractCodeHashFunctionName,
+			emulatorBackendContractCodeHashFunctionType,
+			emulatorBackendContractCodeHashFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendAccountKeysFunctionName,
+			emulatorBackendAccountKeysFunctionType,
+			emulatorBackendAccountKeysFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendFlowTotalSupplyFunctionName,
+			emulatorBackendFlowTotalSupplyFunctionType,
+			emulatorBackendFlowTotalSupplyFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendGetBlockFunctionName,
+			emulatorBackendGetBlockFunctionType,
+			emulatorBackendGetBlockFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendContractImportsFunctionName,
+			emulatorBackendContractImportsFunctionType,
+			emulatorBackendContractImportsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendSetTransactionsPerBlockFunctionName,
+			emulatorBackendSetTransactionsPerBlockFunctionType,
+			emulatorBackendSetTransactionsPerBlockFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendAccountExistsFunctionName,
+			emulatorBackendAccountExistsFunctionType,
+			emulatorBackendAccountExistsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendCallContractFunctionFunctionName,
+			emulatorBackendCallContractFunctionFunctionType,
+			emulatorBackendCallContractFunctionFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendGetContractCodeFunctionName,
+			emulatorBackendGetContractCodeFunctionType,
+			emulatorBackendGetContractCodeFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendSpyOnFunctionName,
+			emulatorBackendSpyOnFunctionType,
+			emulatorBackendSpyOnFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendFunctionCallsFunctionName,
+			emulatorBackendFunctionCallsFunctionType,
+			emulatorBackendFunctionCallsFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendCheckTransactionFunctionName,
+			emulatorBackendCheckTransactionFunctionType,
+			emulatorBackendCheckTransactionFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendContractValueFunctionName,
+			emulatorBackendContractValueFunctionType,
+			emulatorBackendContractValueFunctionDocString,
+		),
+		sema.NewUnmeteredPublicFunctionMember(
+			ty,
+			emulatorBackendPendingTransactionCountFunctionName,
+			emulatorBackendPendingTransactionCountFunctionType,
+			emulatorBackendPendingTransactionCountFunctionDocString,
+		),
 	}
 
 	ty.Members = sema.GetMembersAsMap(members)
@@ -790,6 +1593,10 @@ func newEmulatorBackend(
 			Name:  emulatorBackendExecuteScriptFunctionName,
 			Value: emulatorBackendExecuteScriptFunction(testFramework),
 		},
+		{
+			Name:  emulatorBackendScriptReturnTypeFunctionName,
+			Value: emulatorBackendScriptReturnTypeFunction(testFramework),
+		},
 		{
 			Name:  emulatorBackendCreateAccountFunctionName,
 			Value: emulatorBackendCreateAccountFunction(testFramework),
@@ -809,38 +1616,138 @@ func newEmulatorBackend(
 			Name:  emulatorBackendDeployContractFunctionName,
 			Value: emulatorBackendDeployContractFunction(testFramework),
 		},
+		{
+			Name:  emulatorBackendDeployContractWithResultFunctionName,
+			Value: emulatorBackendDeployContractWithResultFunction(testFramework),
+		},
 		{
 			Name:  emulatorBackendUseConfigFunctionName,
 			Value: emulatorBackendUseConfigFunction(testFramework),
 		},
-	}
-
-	return interpreter.NewCompositeValue(
-		inter,
-		locationRange,
-		EmulatorBackendType.Location,
-		emulatorBackendTypeName,
-		common.CompositeKindStructure,
-		fields,
-		common.ZeroAddress,
-	)
-}
-
-// 'EmulatorBackend.executeScript' function
-
-const emulatorBackendExecuteScriptFunctionName = "executeScript"
-
-const emulatorBackendExecuteScriptFunctionDocString = `
-Executes a script and returns the script return value and the status.
-The 'returnValue' field of the result will be nil if the script failed.
-`
-
-var emulatorBackendExecuteScriptFunctionType = interfaceFunctionType(
-	blockchainBackendInterfaceType,
-	emulatorBackendExecuteScriptFunctionName,
-)
-
-func emulatorBackendExecuteScriptFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+		{
+			Name:  emulatorBackendNextAddressFunctionName,
+			Value: emulatorBackendNextAddressFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendSequenceNumberFunctionName,
+			Value: emulatorBackendSequenceNumberFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendTransactionsInBlockFunctionName,
+			Value: emulatorBackendTransactionsInBlockFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendGetCapabilityFunctionName,
+			Value: emulatorBackendGetCapabilityFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendAllContractsFunctionName,
+			Value: emulatorBackendAllContractsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendExecuteScriptAtHeightFunctionName,
+			Value: emulatorBackendExecuteScriptAtHeightFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendCheckContractFunctionName,
+			Value: emulatorBackendCheckContractFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendEventsFunctionName,
+			Value: emulatorBackendEventsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendRecentEventsFunctionName,
+			Value: emulatorBackendRecentEventsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendClearEventsFunctionName,
+			Value: emulatorBackendClearEventsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendContractCodeHashFunctionName,
+			Value: emulatorBackendContractCodeHashFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendAccountKeysFunctionName,
+			Value: emulatorBackendAccountKeysFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendFlowTotalSupplyFunctionName,
+			Value: emulatorBackendFlowTotalSupplyFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendGetBlockFunctionName,
+			Value: emulatorBackendGetBlockFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendContractImportsFunctionName,
+			Value: emulatorBackendContractImportsFunction,
+		},
+		{
+			Name:  emulatorBackendSetTransactionsPerBlockFunctionName,
+			Value: emulatorBackendSetTransactionsPerBlockFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendAccountExistsFunctionName,
+			Value: emulatorBackendAccountExistsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendCallContractFunctionFunctionName,
+			Value: emulatorBackendCallContractFunctionFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendGetContractCodeFunctionName,
+			Value: emulatorBackendGetContractCodeFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendSpyOnFunctionName,
+			Value: emulatorBackendSpyOnFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendFunctionCallsFunctionName,
+			Value: emulatorBackendFunctionCallsFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendCheckTransactionFunctionName,
+			Value: emulatorBackendCheckTransactionFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendContractValueFunctionName,
+			Value: emulatorBackendContractValueFunction(testFramework),
+		},
+		{
+			Name:  emulatorBackendPendingTransactionCountFunctionName,
+			Value: emulatorBackendPendingTransactionCountFunction(testFramework),
+		},
+	}
+
+	return interpreter.NewCompositeValue(
+		inter,
+		locationRange,
+		EmulatorBackendType.Location,
+		emulatorBackendTypeName,
+		common.CompositeKindStructure,
+		fields,
+		common.ZeroAddress,
+	)
+}
+
+// 'EmulatorBackend.executeScript' function
+
+const emulatorBackendExecuteScriptFunctionName = "executeScript"
+
+const emulatorBackendExecuteScriptFunctionDocString = `
+Executes a script and returns the script return value and the status.
+The 'returnValue' field of the result will be nil if the script failed.
+`
+
+var emulatorBackendExecuteScriptFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendExecuteScriptFunctionName,
+)
+
+func emulatorBackendExecuteScriptFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
 	return interpreter.NewUnmeteredHostFunctionValue(
 		emulatorBackendExecuteScriptFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
@@ -912,6 +1819,7 @@ func newScriptResult(
 			status,
 			returnValue,
 			errValue,
+			newEventsValue(inter, result.Events),
 		},
 	)
 
@@ -922,6 +1830,26 @@ func newScriptResult(
 	return scriptResult
 }
 
+func newDeploymentResult(inter *interpreter.Interpreter, result *DeploymentResult) interpreter.Value {
+	// Create a 'DeploymentResult' by calling its constructor.
+	deploymentResultConstructor := getConstructor(inter, deploymentResultTypeName)
+
+	deploymentResult, err := inter.InvokeExternally(
+		deploymentResultConstructor,
+		deploymentResultConstructor.Type,
+		[]interpreter.Value{
+			newErrorValue(inter, result.Error),
+			interpreter.NewUnmeteredUInt64Value(result.ComputationUsed),
+		},
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return deploymentResult
+}
+
 func getConstructor(inter *interpreter.Interpreter, typeName string) *interpreter.HostFunctionValue {
 	resultStatusConstructorVar := inter.FindVariable(typeName)
 	resultStatusConstructor, ok := resultStatusConstructorVar.GetValue().(*interpreter.HostFunctionValue)
@@ -932,6 +1860,56 @@ func getConstructor(inter *interpreter.Interpreter, typeName string) *interprete
 	return resultStatusConstructor
 }
 
+// 'EmulatorBackend.scriptReturnType' function
+
+const emulatorBackendScriptReturnTypeFunctionName = "scriptReturnType"
+
+const emulatorBackendScriptReturnTypeFunctionDocString = `
+Returns the declared return type of the given script,
+or nil if the script does not return a value.
+`
+
+var emulatorBackendScriptReturnTypeFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendScriptReturnTypeFunctionName,
+)
+
+func emulatorBackendScriptReturnTypeFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendScriptReturnTypeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			framework := testFrameworkExtension[ScriptReturnTypeTestFramework](
+				testFramework,
+				emulatorBackendScriptReturnTypeFunctionName,
+			)
+
+			returnType, err := framework.ScriptReturnType(inter, script.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			// Scripts without a return value have no return type.
+			if returnType == nil || returnType == sema.VoidType {
+				return interpreter.Nil
+			}
+
+			staticType := interpreter.ConvertSemaToStaticType(inter, returnType)
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				interpreter.NewTypeValue(inter, staticType),
+			)
+		},
+	)
+}
+
 // 'EmulatorBackend.createAccount' function
 
 const emulatorBackendCreateAccountFunctionName = "createAccount"
@@ -1076,13 +2054,32 @@ func emulatorBackendAddTransactionFunction(testFramework TestFramework) *interpr
 				panic(errors.NewUnexpectedErrorFromCause(err))
 			}
 
-			err = testFramework.AddTransaction(
-				invocation.Interpreter,
-				code.Str,
-				authorizers,
-				signerAccounts,
-				args,
-			)
+			// Get reference block and expiry
+			expiry := transactionExpiryFromValue(inter, locationRange, transactionValue)
+
+			if expiry == nil {
+				err = testFramework.AddTransaction(
+					invocation.Interpreter,
+					code.Str,
+					authorizers,
+					signerAccounts,
+					args,
+				)
+			} else {
+				framework := testFrameworkExtension[TransactionExpiryTestFramework](
+					testFramework,
+					emulatorBackendAddTransactionFunctionName,
+				)
+
+				err = framework.AddTransactionWithExpiry(
+					invocation.Interpreter,
+					code.Str,
+					authorizers,
+					signerAccounts,
+					args,
+					expiry,
+				)
+			}
 
 			if err != nil {
 				panic(err)
@@ -1093,6 +2090,48 @@ func emulatorBackendAddTransactionFunction(testFramework TestFramework) *interpr
 	)
 }
 
+// transactionExpiryFromValue returns the reference block and expiry of the given transaction,
+// or nil if the transaction uses the defaults of the blockchain.
+func transactionExpiryFromValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	transactionValue interpreter.MemberAccessibleValue,
+) *TransactionExpiry {
+
+	referenceBlockHeightValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		transactionReferenceBlockHeightFieldName,
+	)
+	someReferenceBlockHeight, ok := referenceBlockHeightValue.(*interpreter.SomeValue)
+	if !ok {
+		return nil
+	}
+	referenceBlockHeight, ok := someReferenceBlockHeight.InnerValue(inter, locationRange).(interpreter.UInt64Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	expiryValue := transactionValue.GetMember(
+		inter,
+		locationRange,
+		transactionExpiryFieldName,
+	)
+	someExpiry, ok := expiryValue.(*interpreter.SomeValue)
+	if !ok {
+		return nil
+	}
+	expiry, ok := someExpiry.InnerValue(inter, locationRange).(interpreter.UInt64Value)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return &TransactionExpiry{
+		ReferenceBlockHeight: uint64(referenceBlockHeight),
+		Expiry:               uint64(expiry),
+	}
+}
+
 func addressesFromValue(accountsValue interpreter.Value) []common.Address {
 	accountsArray, ok := accountsValue.(*interpreter.ArrayValue)
 	if !ok {
@@ -1226,10 +2265,22 @@ func newTransactionResult(inter *interpreter.Interpreter, result *TransactionRes
 		status = failedVar.GetValue()
 	}
 
+	// Lookup and get 'ExecutionStatus' enum value.
+	executionStatusConstructor := getConstructor(inter, executionStatusTypeName)
+	executionStatusCase := executionStatusCaseName(result.ExecutionStatus)
+	executionStatus := executionStatusConstructor.NestedVariables[executionStatusCase].GetValue()
+
 	// Create a 'TransactionResult' by calling its constructor.
 	transactionResultConstructor := getConstructor(inter, transactionResultTypeName)
 
-	errValue := newErrorValue(inter, result.Error)
+	// The test framework reports authorizer mismatches,
+	// as the error is defined by the runtime
+	kindCaseName := errorKindCaseName(result.Error)
+	if result.AuthorizerMismatch {
+		kindCaseName = authorizerMismatchErrorKindCaseName
+	}
+
+	errValue := newErrorValueWithKind(inter, result.Error, kindCaseName)
 
 	transactionResult, err := inter.InvokeExternally(
 		transactionResultConstructor,
@@ -1237,6 +2288,13 @@ func newTransactionResult(inter *interpreter.Interpreter, result *TransactionRes
 		[]interpreter.Value{
 			status,
 			errValue,
+			newStoragePathsValue(inter, result.WrittenPaths),
+			newStoragePathsValue(inter, result.ReadPaths),
+			newEventsValue(inter, result.Events),
+			interpreter.NewUnmeteredIntValueFromInt64(int64(result.DestroyedResources)),
+			executionStatus,
+			newComputationBreakdownValue(inter, result.ComputationBreakdown),
+			interpreter.NewUnmeteredIntValueFromInt64(int64(result.TransactionIndex)),
 		},
 	)
 
@@ -1247,15 +2305,140 @@ func newTransactionResult(inter *interpreter.Interpreter, result *TransactionRes
 	return transactionResult
 }
 
+func newComputationBreakdownValue(
+	inter *interpreter.Interpreter,
+	breakdown map[common.ComputationKind]uint64,
+) interpreter.Value {
+	kinds := make([]common.ComputationKind, 0, len(breakdown))
+	for kind := range breakdown {
+		kinds = append(kinds, kind)
+	}
+
+	// Sort the kinds, so the dictionary is constructed deterministically
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+
+	keysAndValues := make([]interpreter.Value, 0, len(kinds)*2)
+	for _, kind := range kinds {
+		keysAndValues = append(
+			keysAndValues,
+			interpreter.NewUnmeteredStringValue(kind.Name()),
+			interpreter.NewUnmeteredUInt64Value(breakdown[kind]),
+		)
+	}
+
+	return interpreter.NewDictionaryValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		interpreter.NewDictionaryStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+			interpreter.PrimitiveStaticTypeUInt64,
+		),
+		keysAndValues...,
+	)
+}
+
+func executionStatusCaseName(status ExecutionStatus) string {
+	switch status {
+	case ExecutionStatusExecuted:
+		return executedExecutionStatusCaseName
+	case ExecutionStatusSealed:
+		return sealedExecutionStatusCaseName
+	case ExecutionStatusExpired:
+		return expiredExecutionStatusCaseName
+	default:
+		panic(errors.NewUnexpectedError("invalid execution status: %d", status))
+	}
+}
+
+func newStoragePathsValue(inter *interpreter.Interpreter, paths []interpreter.PathValue) interpreter.Value {
+	values := make([]interpreter.Value, 0, len(paths))
+	for _, path := range paths {
+		values = append(values, path)
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		interpreter.NewVariableSizedStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeStoragePath,
+		),
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+func newEventsValue(inter *interpreter.Interpreter, events []interpreter.Value) interpreter.Value {
+	return interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		interpreter.NewVariableSizedStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeAnyStruct,
+		),
+		common.ZeroAddress,
+		events...,
+	)
+}
+
+// newErrorsValue returns an array of 'Error' values for the given errors, e.g. checker errors.
+func newErrorsValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	errs []error,
+) interpreter.Value {
+	values := make([]interpreter.Value, 0, len(errs))
+	for _, err := range errs {
+		values = append(values, newErrorValue(inter, err))
+	}
+
+	arrayType := interpreter.NewVariableSizedStaticType(
+		inter,
+		interpreter.ConvertSemaToStaticType(inter, errorType),
+	)
+
+	return interpreter.NewArrayValue(
+		inter,
+		locationRange,
+		arrayType,
+		common.ZeroAddress,
+		values...,
+	)
+}
+
 func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value {
+	return newErrorValueWithKind(inter, err, errorKindCaseName(err))
+}
+
+// errorKindCaseName returns the name of the 'ErrorKind' case of the given error.
+func errorKindCaseName(err error) string {
+	if isConversionError(err) {
+		return conversionErrorKindCaseName
+	} else if isArithmeticError(err) {
+		return arithmeticErrorKindCaseName
+	} else if isPostConditionError(err) {
+		return postConditionErrorKindCaseName
+	}
+
+	return genericErrorKindCaseName
+}
+
+func newErrorValueWithKind(inter *interpreter.Interpreter, err error, kindCaseName string) interpreter.Value {
 	if err == nil {
 		return interpreter.Nil
 	}
 
+	// Lookup and get 'ErrorKind' enum value.
+	errorKindConstructor := getConstructor(inter, errorKindTypeName)
+	kind := errorKindConstructor.NestedVariables[kindCaseName].GetValue()
+
 	// Create a 'Error' by calling its constructor.
 	errorConstructor := getConstructor(inter, errorTypeName)
 
-	errorValue, invocationErr := inter.InvokeExternally(
+	value, invocationErr := inter.InvokeExternally(
 		errorConstructor,
 		errorConstructor.Type,
 		[]interpreter.Value{
@@ -1267,9 +2450,103 @@ func newErrorValue(inter *interpreter.Interpreter, err error) interpreter.Value
 		panic(invocationErr)
 	}
 
+	errorValue, ok := value.(*interpreter.CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Set the kind and the stack trace using 'Error.setDetails',
+	// as the initializer only accepts the message
+
+	setDetails, ok := errorValue.GetMember(
+		inter,
+		interpreter.EmptyLocationRange,
+		errorSetDetailsFunctionName,
+	).(interpreter.FunctionValue)
+	if !ok {
+		panic(errors.NewUnexpectedError(
+			"invalid type for '%s'. expected function",
+			errorSetDetailsFunctionName,
+		))
+	}
+
+	_, invocationErr = inter.InvokeExternally(
+		setDetails,
+		setDetails.FunctionType(),
+		[]interpreter.Value{
+			kind,
+			newStackTraceValue(inter, err),
+		},
+	)
+	if invocationErr != nil {
+		panic(invocationErr)
+	}
+
 	return errorValue
 }
 
+// newStackTraceValue returns the locations of the function calls which led to the given error,
+// outermost call first.
+func newStackTraceValue(inter *interpreter.Interpreter, err error) interpreter.Value {
+	var frames []interpreter.Value
+
+	var interpreterErr interpreter.Error
+	if goErrors.As(err, &interpreterErr) {
+		for _, invocation := range interpreterErr.StackTrace {
+			locationRange := invocation.LocationRange
+			if locationRange.Location == nil {
+				continue
+			}
+
+			position := locationRange.StartPosition()
+
+			frame := fmt.Sprintf(
+				"%s:%d:%d",
+				locationRange.Location,
+				position.Line,
+				position.Column,
+			)
+
+			frames = append(frames, interpreter.NewUnmeteredStringValue(frame))
+		}
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		interpreter.NewVariableSizedStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+		),
+		common.ZeroAddress,
+		frames...,
+	)
+}
+
+// isArithmeticError returns true if the given error was caused
+// by an arithmetic overflow or underflow.
+func isArithmeticError(err error) bool {
+	var overflowErr interpreter.OverflowError
+	var underflowErr interpreter.UnderflowError
+	return goErrors.As(err, &overflowErr) ||
+		goErrors.As(err, &underflowErr)
+}
+
+// isConversionError returns true if the given error was caused
+// by a conversion of a value to another type.
+func isConversionError(err error) bool {
+	var conversionErr interpreter.ConversionError
+	return goErrors.As(err, &conversionErr)
+}
+
+// isPostConditionError returns true if the given error was caused
+// by a failed post-condition.
+func isPostConditionError(err error) bool {
+	var conditionErr interpreter.ConditionError
+	return goErrors.As(err, &conditionErr) &&
+		conditionErr.ConditionKind == ast.ConditionKindPost
+}
+
 // 'EmulatorBackend.commitBlock' function
 
 const emulatorBackendCommitBlockFunctionName = "commitBlock"
@@ -1367,123 +2644,2056 @@ var equalMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
 	},
 )
 
-// 'EmulatorBackend.deployContract' function
+const beCloseToMatcherFunctionName = "beCloseTo"
+
+const beCloseToMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is a UFix64 value
+which differs from the given value by at most the given delta.
+`
+
+var beCloseToMatcherFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.UFix64Type),
+		},
+		{
+			Identifier:     "delta",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.UFix64Type),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var beCloseToMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	beCloseToMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		expected, ok := invocation.Arguments[0].(interpreter.UFix64Value)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		delta, ok := invocation.Arguments[1].(interpreter.UFix64Value)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		return newMatcherWithFailureMessage(
+			invocation,
+			func(
+				inter *interpreter.Interpreter,
+				_ interpreter.LocationRange,
+				value interpreter.Value,
+			) (bool, string) {
+				actual, ok := value.(interpreter.UFix64Value)
+				if !ok {
+					return false, fmt.Sprintf(
+						"expected a value of type `%s`, got `%s`",
+						sema.UFix64Type,
+						value.StaticType(inter),
+					)
+				}
+
+				// Compare the fixed-point integer representations,
+				// which avoids any overflow when computing the difference
+				var difference uint64
+				if actual > expected {
+					difference = uint64(actual - expected)
+				} else {
+					difference = uint64(expected - actual)
+				}
+
+				if difference > uint64(delta) {
+					return false, fmt.Sprintf(
+						"%s is not within %s of %s",
+						actual,
+						delta,
+						expected,
+					)
+				}
+
+				return true, ""
+			},
+		)
+	},
+)
+
+const haveBorrowTypeMatcherFunctionName = "haveBorrowType"
+
+const haveBorrowTypeMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is a capability
+with the given borrow type.
+`
+
+var haveBorrowTypeMatcherFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "type",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.MetaType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var haveBorrowTypeMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	haveBorrowTypeMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		expectedType, ok := invocation.Arguments[0].(interpreter.TypeValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		return newMatcherWithFailureMessage(
+			invocation,
+			func(
+				inter *interpreter.Interpreter,
+				_ interpreter.LocationRange,
+				value interpreter.Value,
+			) (bool, string) {
+				if _, isNil := value.(interpreter.NilValue); isNil {
+					return false, "expected a capability, got nil"
+				}
+
+				capability, ok := value.(*interpreter.StorageCapabilityValue)
+				if !ok {
+					return false, fmt.Sprintf(
+						"expected a capability, got `%s`",
+						value.StaticType(inter),
+					)
+				}
+
+				// Capabilities without a borrow type, and unknown types,
+				// never match
+				if capability.BorrowType == nil {
+					return false, "capability has no borrow type"
+				}
+
+				if expectedType.Type == nil {
+					return false, fmt.Sprintf(
+						"expected an unknown borrow type, got `%s`",
+						capability.BorrowType,
+					)
+				}
+
+				if !capability.BorrowType.Equal(expectedType.Type) {
+					return false, fmt.Sprintf(
+						"expected borrow type `%s`, got `%s`",
+						expectedType.Type,
+						capability.BorrowType,
+					)
+				}
+
+				return true, ""
+			},
+		)
+	},
+)
+
+const beSomeMatcherFunctionName = "beSome"
+
+const beSomeMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is not nil.
+`
+
+var beSomeMatcherFunctionType = &sema.FunctionType{
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var beSomeMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	beSomeMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		return newMatcherWithFailureMessage(
+			invocation,
+			func(
+				_ *interpreter.Interpreter,
+				_ interpreter.LocationRange,
+				value interpreter.Value,
+			) (bool, string) {
+				if _, isNil := value.(interpreter.NilValue); isNil {
+					return false, "expected a value, got nil"
+				}
+
+				return true, ""
+			},
+		)
+	},
+)
+
+const equalDictionaryMatcherFunctionName = "equalDictionary"
+
+const equalDictionaryMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is a dictionary
+with the same key-value pairs as the given dictionary, independent of iteration order
+and of the static types of the dictionaries.
+`
+
+var equalDictionaryMatcherFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.AnyStructType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var equalDictionaryMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	equalDictionaryMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		expected, ok := invocation.Arguments[0].(*interpreter.DictionaryValue)
+		if !ok {
+			panic(errors.NewDefaultUserError("value is not a dictionary"))
+		}
+
+		return newMatcherWithFailureMessage(
+			invocation,
+			func(
+				inter *interpreter.Interpreter,
+				locationRange interpreter.LocationRange,
+				value interpreter.Value,
+			) (bool, string) {
+				actual, ok := value.(*interpreter.DictionaryValue)
+				if !ok {
+					return false, fmt.Sprintf(
+						"expected a dictionary, got `%s`",
+						value.StaticType(inter),
+					)
+				}
+
+				var differences []string
+
+				if actual.Count() != expected.Count() {
+					differences = append(
+						differences,
+						fmt.Sprintf(
+							"expected length %d, got %d",
+							expected.Count(),
+							actual.Count(),
+						),
+					)
+				}
+
+				var missingKeys []string
+				var mismatchedValues []string
+				expected.Iterate(inter, func(key, expectedValue interpreter.Value) (resume bool) {
+					actualValue, ok := actual.Get(inter, locationRange, key)
+					if !ok {
+						missingKeys = append(missingKeys, key.String())
+						return true
+					}
+
+					equatableValue, ok := expectedValue.(interpreter.EquatableValue)
+					if !ok || !equatableValue.Equal(inter, locationRange, actualValue) {
+						mismatchedValues = append(
+							mismatchedValues,
+							fmt.Sprintf(
+								"%s (expected %s, got %s)",
+								key,
+								expectedValue,
+								actualValue,
+							),
+						)
+					}
+
+					return true
+				})
+
+				var extraKeys []string
+				actual.Iterate(inter, func(key, _ interpreter.Value) (resume bool) {
+					if !expected.ContainsKey(inter, locationRange, key) {
+						extraKeys = append(extraKeys, key.String())
+					}
+					return true
+				})
+
+				if len(missingKeys) > 0 {
+					differences = append(
+						differences,
+						fmt.Sprintf("missing keys: %s", strings.Join(missingKeys, ", ")),
+					)
+				}
+
+				if len(extraKeys) > 0 {
+					differences = append(
+						differences,
+						fmt.Sprintf("extra keys: %s", strings.Join(extraKeys, ", ")),
+					)
+				}
+
+				if len(mismatchedValues) > 0 {
+					differences = append(
+						differences,
+						fmt.Sprintf("mismatched values: %s", strings.Join(mismatchedValues, ", ")),
+					)
+				}
+
+				if len(differences) > 0 {
+					return false, fmt.Sprintf(
+						"dictionaries differ: %s",
+						strings.Join(differences, "; "),
+					)
+				}
+
+				return true, ""
+			},
+		)
+	},
+)
+
+const haveLengthMatcherFunctionName = "haveLength"
+
+const haveLengthMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is an array, a dictionary,
+or a string, and has the given length.
+`
+
+var haveLengthMatcherFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "length",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var haveLengthMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	haveLengthMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		lengthValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		expectedLength := lengthValue.ToInt(invocation.LocationRange)
+
+		return newMatcherWithFailureMessage(
+			invocation,
+			func(
+				inter *interpreter.Interpreter,
+				_ interpreter.LocationRange,
+				value interpreter.Value,
+			) (bool, string) {
+				var length int
+
+				switch value := value.(type) {
+				case *interpreter.ArrayValue:
+					length = value.Count()
+				case *interpreter.DictionaryValue:
+					length = value.Count()
+				case *interpreter.StringValue:
+					length = value.Length()
+				default:
+					return false, fmt.Sprintf(
+						"expected an array, a dictionary, or a string, got `%s`",
+						value.StaticType(inter),
+					)
+				}
+
+				if length != expectedLength {
+					return false, fmt.Sprintf(
+						"expected length %d, got %d",
+						expectedLength,
+						length,
+					)
+				}
+
+				return true, ""
+			},
+		)
+	},
+)
+
+const beInRangeMatcherFunctionName = "beInRange"
+
+const beInRangeMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is a number
+which is greater than or equal to the given low bound,
+and less than or equal to the given high bound.
+The tested value must have the same type as the bounds.
+`
+
+var beInRangeMatcherFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "low",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.NumberType),
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "high",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.NumberType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(matcherType),
+}
+
+var beInRangeMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	beInRangeMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		return newBeInRangeMatcher(invocation, false)
+	},
+)
+
+// newBeInRangeMatcher returns a matcher that succeeds if the tested value
+// is within the bounds given as the arguments of the invocation.
+// The bounds are included, unless exclusive is true.
+func newBeInRangeMatcher(invocation interpreter.Invocation, exclusive bool) interpreter.Value {
+	low, ok := invocation.Arguments[0].(interpreter.NumberValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	high, ok := invocation.Arguments[1].(interpreter.NumberValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter := invocation.Interpreter
+	locationRange := invocation.LocationRange
+
+	boundType := low.StaticType(inter)
+	if !boundType.Equal(high.StaticType(inter)) {
+		panic(errors.NewDefaultUserError(
+			"bounds must have the same type: got `%s` and `%s`",
+			boundType,
+			high.StaticType(inter),
+		))
+	}
+
+	if low.Greater(inter, high, locationRange) {
+		panic(errors.NewDefaultUserError(
+			"low bound %s is greater than high bound %s",
+			low,
+			high,
+		))
+	}
+
+	return newMatcherWithFailureMessage(
+		invocation,
+		func(
+			inter *interpreter.Interpreter,
+			locationRange interpreter.LocationRange,
+			value interpreter.Value,
+		) (bool, string) {
+			number, ok := value.(interpreter.NumberValue)
+			if !ok || !number.StaticType(inter).Equal(boundType) {
+				return false, fmt.Sprintf(
+					"expected a value of type `%s`, got `%s`",
+					boundType,
+					value.StaticType(inter),
+				)
+			}
+
+			if exclusive {
+				if !number.Greater(inter, low, locationRange) {
+					return false, fmt.Sprintf(
+						"%s is not greater than the exclusive low bound %s",
+						number,
+						low,
+					)
+				}
+
+				if !number.Less(inter, high, locationRange) {
+					return false, fmt.Sprintf(
+						"%s is not less than the exclusive high bound %s",
+						number,
+						high,
+					)
+				}
+			} else {
+				if number.Less(inter, low, locationRange) {
+					return false, fmt.Sprintf(
+						"%s is less than the low bound %s",
+						number,
+						low,
+					)
+				}
+
+				if number.Greater(inter, high, locationRange) {
+					return false, fmt.Sprintf(
+						"%s is greater than the high bound %s",
+						number,
+						high,
+					)
+				}
+			}
+
+			return true, ""
+		},
+	)
+}
+
+const beInExclusiveRangeMatcherFunctionName = "beInExclusiveRange"
+
+const beInExclusiveRangeMatcherFunctionDocString = `
+Returns a matcher that succeeds if the tested value is a number
+which is greater than the given low bound, and less than the given high bound.
+The tested value must have the same type as the bounds.
+`
+
+var beInExclusiveRangeMatcherFunctionType = beInRangeMatcherFunctionType
+
+var beInExclusiveRangeMatcherFunction = interpreter.NewUnmeteredHostFunctionValue(
+	beInExclusiveRangeMatcherFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		return newBeInRangeMatcher(invocation, true)
+	},
+)
+
+// 'EmulatorBackend.deployContract' function
 
 const emulatorBackendDeployContractFunctionName = "deployContract"
 
-const emulatorBackendDeployContractFunctionDocString = `
-Deploys a given contract, and initializes it with the provided arguments.
+const emulatorBackendDeployContractFunctionDocString = `
+Deploys a given contract, and initializes it with the provided arguments.
+`
+
+var emulatorBackendDeployContractFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendDeployContractFunctionName,
+)
+
+func emulatorBackendDeployContractFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendDeployContractFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			// Contract name
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Contract code
+			code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// authorizer
+			accountValue, ok := invocation.Arguments[2].(interpreter.MemberAccessibleValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			account := accountFromValue(inter, accountValue, invocation.LocationRange)
+
+			// Contract init arguments
+			args, err := arrayValueToSlice(invocation.Arguments[3])
+			if err != nil {
+				panic(err)
+			}
+
+			err = testFramework.DeployContract(
+				inter,
+				name.Str,
+				code.Str,
+				account,
+				args,
+			)
+
+			return newErrorValue(inter, err)
+		},
+	)
+}
+
+// 'EmulatorBackend.deployContractWithResult' function
+
+const emulatorBackendDeployContractWithResultFunctionName = "deployContractWithResult"
+
+const emulatorBackendDeployContractWithResultFunctionDocString = `
+Deploys a given contract, and initializes it with the provided arguments.
+Returns the result of the deployment, including the computation used.
+`
+
+var emulatorBackendDeployContractWithResultFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendDeployContractWithResultFunctionName,
+)
+
+func emulatorBackendDeployContractWithResultFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendDeployContractWithResultFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			// Contract name
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// Contract code
+			code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			// authorizer
+			accountValue, ok := invocation.Arguments[2].(interpreter.MemberAccessibleValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			account := accountFromValue(inter, accountValue, invocation.LocationRange)
+
+			// Contract init arguments
+			args, err := arrayValueToSlice(invocation.Arguments[3])
+			if err != nil {
+				panic(err)
+			}
+
+			var result *DeploymentResult
+
+			if framework, ok := testFramework.(DeploymentResultTestFramework); ok {
+				result = framework.DeployContractWithResult(
+					inter,
+					name.Str,
+					code.Str,
+					account,
+					args,
+				)
+			} else {
+				// The computation used by the deployment is unknown
+				err := testFramework.DeployContract(
+					inter,
+					name.Str,
+					code.Str,
+					account,
+					args,
+				)
+				result = &DeploymentResult{
+					Error: err,
+				}
+			}
+
+			return newDeploymentResult(inter, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.useConfiguration' function
+
+const emulatorBackendUseConfigFunctionName = "useConfiguration"
+
+const emulatorBackendUseConfigFunctionDocString = `Use configurations function`
+
+var emulatorBackendUseConfigFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendUseConfigFunctionName,
+)
+
+func emulatorBackendUseConfigFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendUseConfigFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			// configurations
+			configsValue, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			addresses, ok := configsValue.GetMember(
+				inter,
+				invocation.LocationRange,
+				addressesFieldName,
+			).(*interpreter.DictionaryValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			mapping := make(map[string]common.Address, addresses.Count())
+
+			addresses.Iterate(nil, func(locationValue, addressValue interpreter.Value) bool {
+				location, ok := locationValue.(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				address, ok := addressValue.(interpreter.AddressValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				mapping[location.Str] = common.Address(address)
+
+				return true
+			})
+
+			testFramework.UseConfiguration(&Configuration{
+				Addresses: mapping,
+			})
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'EmulatorBackend.nextAddress' function
+
+const emulatorBackendNextAddressFunctionName = "nextAddress"
+
+const emulatorBackendNextAddressFunctionDocString = `
+Returns the address that will be assigned to the next created account.
+Addresses are allocated deterministically, in sequence.
+`
+
+var emulatorBackendNextAddressFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendNextAddressFunctionName,
+)
+
+func emulatorBackendNextAddressFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendNextAddressFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			framework := testFrameworkExtension[NextAddressTestFramework](
+				testFramework,
+				emulatorBackendNextAddressFunctionName,
+			)
+
+			address := framework.NextAddress()
+
+			return interpreter.NewAddressValue(invocation.Interpreter, address)
+		},
+	)
+}
+
+// 'EmulatorBackend.sequenceNumber' function
+
+const emulatorBackendSequenceNumberFunctionName = "sequenceNumber"
+
+const emulatorBackendSequenceNumberFunctionDocString = `
+Returns the current sequence number of the given key of the given account.
+`
+
+var emulatorBackendSequenceNumberFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendSequenceNumberFunctionName,
+)
+
+func emulatorBackendSequenceNumberFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendSequenceNumberFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			keyIndexValue, ok := invocation.Arguments[1].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			keyIndex := keyIndexValue.ToInt(invocation.LocationRange)
+
+			framework := testFrameworkExtension[SequenceNumberTestFramework](
+				testFramework,
+				emulatorBackendSequenceNumberFunctionName,
+			)
+
+			sequenceNumber, err := framework.SequenceNumber(
+				common.Address(address),
+				keyIndex,
+			)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewUInt64Value(
+				invocation.Interpreter,
+				func() uint64 {
+					return sequenceNumber
+				},
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.transactionsInBlock' function
+
+const emulatorBackendTransactionsInBlockFunctionName = "transactionsInBlock"
+
+const emulatorBackendTransactionsInBlockFunctionDocString = `
+Returns the results of the transactions committed in the block with the given height.
+Returns an empty array if there is no committed block with the given height.
+`
+
+var emulatorBackendTransactionsInBlockFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendTransactionsInBlockFunctionName,
+)
+
+func emulatorBackendTransactionsInBlockFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendTransactionsInBlockFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			height, ok := invocation.Arguments[0].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[TransactionsInBlockTestFramework](
+				testFramework,
+				emulatorBackendTransactionsInBlockFunctionName,
+			)
+
+			results, err := framework.TransactionsInBlock(uint64(height))
+			if err != nil {
+				panic(err)
+			}
+
+			inter := invocation.Interpreter
+
+			values := make([]interpreter.Value, 0, len(results))
+			for _, result := range results {
+				values = append(values, newTransactionResult(inter, result))
+			}
+
+			arrayType := interpreter.NewVariableSizedStaticType(
+				inter,
+				interpreter.ConvertSemaToStaticType(inter, transactionResultType),
+			)
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.LocationRange,
+				arrayType,
+				common.ZeroAddress,
+				values...,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.getCapability' function
+
+const emulatorBackendGetCapabilityFunctionName = "getCapability"
+
+const emulatorBackendGetCapabilityFunctionDocString = `
+Returns the capability published at the given path of the given account,
+or nil if no capability is published at the path.
+`
+
+var emulatorBackendGetCapabilityFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendGetCapabilityFunctionName,
+)
+
+func emulatorBackendGetCapabilityFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendGetCapabilityFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			path, ok := invocation.Arguments[1].(interpreter.PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[GetCapabilityTestFramework](
+				testFramework,
+				emulatorBackendGetCapabilityFunctionName,
+			)
+
+			capability, err := framework.GetCapability(common.Address(address), path)
+			if err != nil {
+				panic(err)
+			}
+
+			// If nothing is published at the path, then return 'nil'.
+			if capability == nil {
+				return interpreter.Nil
+			}
+
+			return interpreter.NewSomeValueNonCopying(invocation.Interpreter, capability)
+		},
+	)
+}
+
+// 'EmulatorBackend.allContracts' function
+
+const emulatorBackendAllContractsFunctionName = "allContracts"
+
+const emulatorBackendAllContractsFunctionDocString = `
+Returns the names of the contracts deployed to each account of the blockchain.
+System contracts are only included if requested.
+`
+
+var emulatorBackendAllContractsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendAllContractsFunctionName,
+)
+
+func emulatorBackendAllContractsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendAllContractsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			includeSystemContracts, ok := invocation.Arguments[0].(interpreter.BoolValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[AllContractsTestFramework](
+				testFramework,
+				emulatorBackendAllContractsFunctionName,
+			)
+
+			contracts, err := framework.AllContracts(bool(includeSystemContracts))
+			if err != nil {
+				panic(err)
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			// Sort the addresses, so the result is deterministic
+			addresses := make([]common.Address, 0, len(contracts))
+			for address := range contracts {
+				addresses = append(addresses, address)
+			}
+			sort.Slice(addresses, func(i, j int) bool {
+				return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+			})
+
+			namesType := interpreter.NewVariableSizedStaticType(
+				inter,
+				interpreter.PrimitiveStaticTypeString,
+			)
+
+			keysAndValues := make([]interpreter.Value, 0, len(addresses)*2)
+			for _, address := range addresses {
+				names := contracts[address]
+
+				nameValues := make([]interpreter.Value, 0, len(names))
+				for _, name := range names {
+					nameValues = append(nameValues, interpreter.NewUnmeteredStringValue(name))
+				}
+
+				keysAndValues = append(
+					keysAndValues,
+					interpreter.NewAddressValue(inter, address),
+					interpreter.NewArrayValue(
+						inter,
+						locationRange,
+						namesType,
+						common.ZeroAddress,
+						nameValues...,
+					),
+				)
+			}
+
+			dictionaryType := interpreter.NewDictionaryStaticType(
+				inter,
+				interpreter.PrimitiveStaticTypeAddress,
+				namesType,
+			)
+
+			return interpreter.NewDictionaryValue(
+				inter,
+				locationRange,
+				dictionaryType,
+				keysAndValues...,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.executeScriptAtHeight' function
+
+const emulatorBackendExecuteScriptAtHeightFunctionName = "executeScriptAtHeight"
+
+const emulatorBackendExecuteScriptAtHeightFunctionDocString = `
+Executes a script against the committed state as of the block with the given height,
+and returns the script return value and the status.
+`
+
+var emulatorBackendExecuteScriptAtHeightFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendExecuteScriptAtHeightFunctionName,
+)
+
+func emulatorBackendExecuteScriptAtHeightFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendExecuteScriptAtHeightFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			script, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			args, err := arrayValueToSlice(invocation.Arguments[1])
+			if err != nil {
+				panic(errors.NewUnexpectedErrorFromCause(err))
+			}
+
+			height, ok := invocation.Arguments[2].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			inter := invocation.Interpreter
+
+			framework := testFrameworkExtension[RunScriptAtHeightTestFramework](
+				testFramework,
+				emulatorBackendExecuteScriptAtHeightFunctionName,
+			)
+
+			result := framework.RunScriptAtHeight(inter, script.Str, args, uint64(height))
+
+			return newScriptResult(inter, result.Value, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.checkContract' function
+
+const emulatorBackendCheckContractFunctionName = "checkContract"
+
+const emulatorBackendCheckContractFunctionDocString = `
+Parses and checks the given contract, without deploying it.
+Returns the errors reported for the contract, if any.
+`
+
+var emulatorBackendCheckContractFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendCheckContractFunctionName,
+)
+
+func emulatorBackendCheckContractFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendCheckContractFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[CheckContractTestFramework](
+				testFramework,
+				emulatorBackendCheckContractFunctionName,
+			)
+
+			checkErrs := framework.CheckContract(name.Str, code.Str)
+
+			return newErrorsValue(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				checkErrs,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.events' function
+
+const emulatorBackendEventsFunctionName = "events"
+
+const emulatorBackendEventsFunctionDocString = `
+Returns all events emitted by the transactions executed on the blockchain, in emission order.
+`
+
+var emulatorBackendEventsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendEventsFunctionName,
+)
+
+func emulatorBackendEventsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendEventsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			framework := testFrameworkExtension[EventsTestFramework](
+				testFramework,
+				emulatorBackendEventsFunctionName,
+			)
+
+			events := framework.Events(inter)
+
+			return newEventsValue(inter, events)
+		},
+	)
+}
+
+// 'EmulatorBackend.recentEvents' function
+
+const emulatorBackendRecentEventsFunctionName = "recentEvents"
+
+const emulatorBackendRecentEventsFunctionDocString = `
+Returns the events emitted by the transactions executed since the last call to clearEvents, in emission order.
+Committing a block does not reset the returned events.
+`
+
+var emulatorBackendRecentEventsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendRecentEventsFunctionName,
+)
+
+func emulatorBackendRecentEventsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendRecentEventsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			framework := testFrameworkExtension[EventsTestFramework](
+				testFramework,
+				emulatorBackendRecentEventsFunctionName,
+			)
+
+			events := framework.RecentEvents(inter)
+
+			return newEventsValue(inter, events)
+		},
+	)
+}
+
+// 'EmulatorBackend.clearEvents' function
+
+const emulatorBackendClearEventsFunctionName = "clearEvents"
+
+const emulatorBackendClearEventsFunctionDocString = `
+Clears the events emitted so far, without affecting any other state of the blockchain.
+`
+
+var emulatorBackendClearEventsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendClearEventsFunctionName,
+)
+
+func emulatorBackendClearEventsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendClearEventsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			framework := testFrameworkExtension[EventsTestFramework](
+				testFramework,
+				emulatorBackendClearEventsFunctionName,
+			)
+
+			framework.ClearEvents()
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'EmulatorBackend.contractCodeHash' function
+
+const emulatorBackendContractCodeHashFunctionName = "contractCodeHash"
+
+const emulatorBackendContractCodeHashFunctionDocString = `
+Returns the SHA3-256 hash of the code of the contract with the given name,
+deployed to the account with the given address.
+`
+
+var emulatorBackendContractCodeHashFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendContractCodeHashFunctionName,
+)
+
+func emulatorBackendContractCodeHashFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendContractCodeHashFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			name, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[ContractCodeTestFramework](
+				testFramework,
+				emulatorBackendContractCodeHashFunctionName,
+			)
+
+			code, found, err := framework.ContractCode(common.Address(address), name.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			if !found {
+				panic(errors.NewDefaultUserError(
+					"contract `%s` is not deployed to account %s",
+					name.Str,
+					address,
+				))
+			}
+
+			return CodeToHashValue(invocation.Interpreter, code)
+		},
+	)
+}
+
+// 'EmulatorBackend.accountKeys' function
+
+const emulatorBackendAccountKeysFunctionName = "accountKeys"
+
+const emulatorBackendAccountKeysFunctionDocString = `
+Returns the keys of the account with the given address, including revoked keys.
+`
+
+var emulatorBackendAccountKeysFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendAccountKeysFunctionName,
+)
+
+func emulatorBackendAccountKeysFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendAccountKeysFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[AccountKeysTestFramework](
+				testFramework,
+				emulatorBackendAccountKeysFunctionName,
+			)
+
+			accountKeys, err := framework.AccountKeys(common.Address(address))
+			if err != nil {
+				panic(err)
+			}
+
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			standardLibraryHandler := testFramework.StandardLibraryHandler()
+
+			values := make([]interpreter.Value, 0, len(accountKeys))
+			for _, accountKey := range accountKeys {
+				values = append(
+					values,
+					NewAccountKeyValue(
+						inter,
+						locationRange,
+						accountKey,
+						standardLibraryHandler,
+						standardLibraryHandler,
+						standardLibraryHandler,
+					),
+				)
+			}
+
+			arrayType := interpreter.NewVariableSizedStaticType(
+				inter,
+				interpreter.PrimitiveStaticTypeAccountKey,
+			)
+
+			return interpreter.NewArrayValue(
+				inter,
+				locationRange,
+				arrayType,
+				common.ZeroAddress,
+				values...,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.flowTotalSupply' function
+
+const emulatorBackendFlowTotalSupplyFunctionName = "flowTotalSupply"
+
+const emulatorBackendFlowTotalSupplyFunctionDocString = `
+Returns the total supply of FLOW tokens.
+`
+
+var emulatorBackendFlowTotalSupplyFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendFlowTotalSupplyFunctionName,
+)
+
+func emulatorBackendFlowTotalSupplyFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendFlowTotalSupplyFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			framework := testFrameworkExtension[FlowTotalSupplyTestFramework](
+				testFramework,
+				emulatorBackendFlowTotalSupplyFunctionName,
+			)
+
+			totalSupply, err := framework.FlowTotalSupply()
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.NewUFix64Value(
+				invocation.Interpreter,
+				func() uint64 {
+					return totalSupply
+				},
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.getBlock' function
+
+const emulatorBackendGetBlockFunctionName = "getBlock"
+
+const emulatorBackendGetBlockFunctionDocString = `
+Returns the committed block with the given height, or nil if there is no such block.
+`
+
+var emulatorBackendGetBlockFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendGetBlockFunctionName,
+)
+
+func emulatorBackendGetBlockFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendGetBlockFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			height, ok := invocation.Arguments[0].(interpreter.UInt64Value)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[GetBlockTestFramework](
+				testFramework,
+				emulatorBackendGetBlockFunctionName,
+			)
+
+			block, err := framework.GetBlock(uint64(height))
+			if err != nil {
+				panic(err)
+			}
+
+			if block == nil {
+				return interpreter.Nil
+			}
+
+			inter := invocation.Interpreter
+
+			return interpreter.NewSomeValueNonCopying(
+				inter,
+				newCommittedBlockValue(inter, invocation.LocationRange, block),
+			)
+		},
+	)
+}
+
+// newCommittedBlockValue creates a 'CommittedBlock' for the given committed block.
+func newCommittedBlockValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	block *CommittedBlock,
+) interpreter.Value {
+
+	// Reuse the conversion of the built-in block type
+	blockValue, ok := NewBlockValue(inter, locationRange, block.Block).(interpreter.MemberAccessibleValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	getField := func(name string) interpreter.Value {
+		return blockValue.GetMember(inter, locationRange, name)
+	}
+
+	// Create a 'CommittedBlock' by calling its constructor.
+	committedBlockConstructor := getConstructor(inter, committedBlockTypeName)
+	committedBlockValue, err := inter.InvokeExternally(
+		committedBlockConstructor,
+		committedBlockConstructor.Type,
+		[]interpreter.Value{
+			getField(sema.BlockTypeHeightFieldName),
+			getField(sema.BlockTypeViewFieldName),
+			getField(sema.BlockTypeIdFieldName),
+			getField(sema.BlockTypeTimestampFieldName),
+			interpreter.NewIntValueFromInt64(inter, int64(block.TransactionCount)),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return committedBlockValue
+}
+
+// 'EmulatorBackend.contractImports' function
+
+const emulatorBackendContractImportsFunctionName = "contractImports"
+
+const emulatorBackendContractImportsFunctionDocString = `
+Parses the contract with the given name and code, and returns the locations it imports.
+Address locations include the imported identifiers, e.g. 0x0000000000000001.Foo.
+Fails if the code cannot be parsed, or does not declare a contract with the given name.
+`
+
+var emulatorBackendContractImportsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendContractImportsFunctionName,
+)
+
+var emulatorBackendContractImportsFunction = interpreter.NewUnmeteredHostFunctionValue(
+	emulatorBackendContractImportsFunctionType,
+	func(invocation interpreter.Invocation) interpreter.Value {
+		name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		program, err := parser.ParseProgram(nil, []byte(code.Str), parser.Config{})
+		if err != nil {
+			panic(ContractParsingError{
+				Name: name.Str,
+				Err:  err,
+			})
+		}
+
+		if !declaresContract(program, name.Str) {
+			panic(ContractNotDeclaredError{
+				Name: name.Str,
+			})
+		}
+
+		inter := invocation.Interpreter
+
+		// Several import declarations may import the same location,
+		// only report each location once
+
+		seen := map[string]struct{}{}
+		var locations []interpreter.Value
+
+		addLocation := func(location string) {
+			if _, ok := seen[location]; ok {
+				return
+			}
+			seen[location] = struct{}{}
+
+			locations = append(
+				locations,
+				interpreter.NewUnmeteredStringValue(location),
+			)
+		}
+
+		for _, declaration := range program.ImportDeclarations() {
+			switch location := declaration.Location.(type) {
+			case common.AddressLocation:
+				address := location.Address.HexWithPrefix()
+
+				// An address import without identifiers imports all contracts of the account
+				if len(declaration.Identifiers) == 0 {
+					addLocation(address)
+					continue
+				}
+
+				for _, identifier := range declaration.Identifiers {
+					addLocation(fmt.Sprintf("%s.%s", address, identifier.Identifier))
+				}
+
+			default:
+				addLocation(location.String())
+			}
+		}
+
+		return interpreter.NewArrayValue(
+			inter,
+			invocation.LocationRange,
+			interpreter.NewVariableSizedStaticType(
+				inter,
+				interpreter.PrimitiveStaticTypeString,
+			),
+			common.ZeroAddress,
+			locations...,
+		)
+	},
+)
+
+// declaresContract returns true if the given program declares
+// a contract or contract interface with the given name.
+func declaresContract(program *ast.Program, name string) bool {
+	for _, declaration := range program.CompositeDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract &&
+			declaration.Identifier.Identifier == name {
+
+			return true
+		}
+	}
+
+	for _, declaration := range program.InterfaceDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract &&
+			declaration.Identifier.Identifier == name {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// 'EmulatorBackend.setTransactionsPerBlock' function
+
+const emulatorBackendSetTransactionsPerBlockFunctionName = "setTransactionsPerBlock"
+
+const emulatorBackendSetTransactionsPerBlockFunctionDocString = `
+Sets the maximum number of transactions per block.
+The block is committed automatically once the maximum number of transactions is executed.
+`
+
+var emulatorBackendSetTransactionsPerBlockFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendSetTransactionsPerBlockFunctionName,
+)
+
+func emulatorBackendSetTransactionsPerBlockFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendSetTransactionsPerBlockFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			countValue, ok := invocation.Arguments[0].(interpreter.IntValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			count := countValue.ToInt(invocation.LocationRange)
+			if count <= 0 {
+				panic(errors.NewDefaultUserError(
+					"transactions per block must be positive, got %d",
+					count,
+				))
+			}
+
+			framework := testFrameworkExtension[SetTransactionsPerBlockTestFramework](
+				testFramework,
+				emulatorBackendSetTransactionsPerBlockFunctionName,
+			)
+
+			err := framework.SetTransactionsPerBlock(count)
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// 'EmulatorBackend.accountExists' function
+
+const emulatorBackendAccountExistsFunctionName = "accountExists"
+
+const emulatorBackendAccountExistsFunctionDocString = `
+Returns true if an account with the given address exists.
+`
+
+var emulatorBackendAccountExistsFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendAccountExistsFunctionName,
+)
+
+func emulatorBackendAccountExistsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendAccountExistsFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[AccountExistsTestFramework](
+				testFramework,
+				emulatorBackendAccountExistsFunctionName,
+			)
+
+			exists, err := framework.AccountExists(common.Address(address))
+			if err != nil {
+				panic(err)
+			}
+
+			return interpreter.AsBoolValue(exists)
+		},
+	)
+}
+
+// 'EmulatorBackend.callContractFunction' function
+
+const emulatorBackendCallContractFunctionFunctionName = "callContractFunction"
+
+const emulatorBackendCallContractFunctionFunctionDocString = `
+Invokes the public function with the given name of the contract with the given name,
+deployed to the account with the given address, with the given arguments,
+and returns the result.
+`
+
+var emulatorBackendCallContractFunctionFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendCallContractFunctionFunctionName,
+)
+
+func emulatorBackendCallContractFunctionFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendCallContractFunctionFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			name, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			function, ok := invocation.Arguments[2].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			args, err := arrayValueToSlice(invocation.Arguments[3])
+			if err != nil {
+				panic(errors.NewUnexpectedErrorFromCause(err))
+			}
+
+			inter := invocation.Interpreter
+
+			framework := testFrameworkExtension[CallContractFunctionTestFramework](
+				testFramework,
+				emulatorBackendCallContractFunctionFunctionName,
+			)
+
+			result := framework.CallContractFunction(
+				inter,
+				common.Address(address),
+				name.Str,
+				function.Str,
+				args,
+			)
+
+			return newScriptResult(inter, result.Value, result)
+		},
+	)
+}
+
+// 'EmulatorBackend.getContractCode' function
+
+const emulatorBackendGetContractCodeFunctionName = "getContractCode"
+
+const emulatorBackendGetContractCodeFunctionDocString = `
+Returns the source code of the contract with the given name,
+deployed to the account with the given address,
+or nil if no such contract is deployed.
+`
+
+var emulatorBackendGetContractCodeFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendGetContractCodeFunctionName,
+)
+
+func emulatorBackendGetContractCodeFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendGetContractCodeFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			name, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[ContractCodeTestFramework](
+				testFramework,
+				emulatorBackendGetContractCodeFunctionName,
+			)
+
+			code, found, err := framework.ContractCode(common.Address(address), name.Str)
+			if err != nil {
+				panic(err)
+			}
+
+			if !found {
+				return interpreter.Nil
+			}
+
+			return interpreter.NewSomeValueNonCopying(
+				invocation.Interpreter,
+				interpreter.NewUnmeteredStringValue(string(code)),
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.spyOn' function
+
+const emulatorBackendSpyOnFunctionName = "spyOn"
+
+const emulatorBackendSpyOnFunctionDocString = `
+Starts recording the invocations of the public function with the given name
+of the contract with the given name, deployed to the account with the given address.
+`
+
+var emulatorBackendSpyOnFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendSpyOnFunctionName,
+)
+
+func emulatorBackendSpyOnFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendSpyOnFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			contractName, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			functionName, ok := invocation.Arguments[2].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			framework := testFrameworkExtension[SpyTestFramework](
+				testFramework,
+				emulatorBackendSpyOnFunctionName,
+			)
+
+			err := framework.SpyOn(
+				common.Address(address),
+				contractName.Str,
+				functionName.Str,
+			)
+
+			return newErrorValue(invocation.Interpreter, err)
+		},
+	)
+}
+
+// 'EmulatorBackend.functionCalls' function
+
+const emulatorBackendFunctionCallsFunctionName = "functionCalls"
+
+const emulatorBackendFunctionCallsFunctionDocString = `
+Returns the arguments of the recorded invocations of the public function with the given name
+of the contract with the given name, deployed to the account with the given address,
+in invocation order.
 `
 
-var emulatorBackendDeployContractFunctionType = interfaceFunctionType(
+var emulatorBackendFunctionCallsFunctionType = interfaceFunctionType(
 	blockchainBackendInterfaceType,
-	emulatorBackendDeployContractFunctionName,
+	emulatorBackendFunctionCallsFunctionName,
 )
 
-func emulatorBackendDeployContractFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+func emulatorBackendFunctionCallsFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
 	return interpreter.NewUnmeteredHostFunctionValue(
-		emulatorBackendDeployContractFunctionType,
+		emulatorBackendFunctionCallsFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			inter := invocation.Interpreter
-
-			// Contract name
-			name, ok := invocation.Arguments[0].(*interpreter.StringValue)
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			// Contract code
-			code, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			contractName, ok := invocation.Arguments[1].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			// authorizer
-			accountValue, ok := invocation.Arguments[2].(interpreter.MemberAccessibleValue)
+			functionName, ok := invocation.Arguments[2].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			account := accountFromValue(inter, accountValue, invocation.LocationRange)
+			framework := testFrameworkExtension[SpyTestFramework](
+				testFramework,
+				emulatorBackendFunctionCallsFunctionName,
+			)
 
-			// Contract init arguments
-			args, err := arrayValueToSlice(invocation.Arguments[3])
+			calls, err := framework.FunctionCalls(
+				common.Address(address),
+				contractName.Str,
+				functionName.Str,
+			)
 			if err != nil {
 				panic(err)
 			}
 
-			err = testFramework.DeployContract(
+			inter := invocation.Interpreter
+
+			argumentsType := interpreter.NewVariableSizedStaticType(
 				inter,
-				name.Str,
-				code.Str,
-				account,
-				args,
+				interpreter.PrimitiveStaticTypeAnyStruct,
 			)
 
-			return newErrorValue(inter, err)
+			values := make([]interpreter.Value, 0, len(calls))
+			for _, arguments := range calls {
+				values = append(
+					values,
+					interpreter.NewArrayValue(
+						inter,
+						invocation.LocationRange,
+						argumentsType,
+						common.ZeroAddress,
+						arguments...,
+					),
+				)
+			}
+
+			return interpreter.NewArrayValue(
+				inter,
+				invocation.LocationRange,
+				interpreter.NewVariableSizedStaticType(inter, argumentsType),
+				common.ZeroAddress,
+				values...,
+			)
 		},
 	)
 }
 
-// 'EmulatorBackend.useConfiguration' function
+// 'EmulatorBackend.checkTransaction' function
 
-const emulatorBackendUseConfigFunctionName = "useConfiguration"
+const emulatorBackendCheckTransactionFunctionName = "checkTransaction"
 
-const emulatorBackendUseConfigFunctionDocString = `Use configurations function`
+const emulatorBackendCheckTransactionFunctionDocString = `
+Parses and checks the given transaction code without executing it,
+and returns the errors, if any.
+`
 
-var emulatorBackendUseConfigFunctionType = interfaceFunctionType(
+var emulatorBackendCheckTransactionFunctionType = interfaceFunctionType(
 	blockchainBackendInterfaceType,
-	emulatorBackendUseConfigFunctionName,
+	emulatorBackendCheckTransactionFunctionName,
 )
 
-func emulatorBackendUseConfigFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+func emulatorBackendCheckTransactionFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
 	return interpreter.NewUnmeteredHostFunctionValue(
-		emulatorBackendUseConfigFunctionType,
+		emulatorBackendCheckTransactionFunctionType,
 		func(invocation interpreter.Invocation) interpreter.Value {
-			inter := invocation.Interpreter
-
-			// configurations
-			configsValue, ok := invocation.Arguments[0].(*interpreter.CompositeValue)
+			code, ok := invocation.Arguments[0].(*interpreter.StringValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			addresses, ok := configsValue.GetMember(
-				inter,
+			framework := testFrameworkExtension[CheckTransactionTestFramework](
+				testFramework,
+				emulatorBackendCheckTransactionFunctionName,
+			)
+
+			checkErrs := framework.CheckTransaction(code.Str)
+
+			return newErrorsValue(
+				invocation.Interpreter,
 				invocation.LocationRange,
-				addressesFieldName,
-			).(*interpreter.DictionaryValue)
+				checkErrs,
+			)
+		},
+	)
+}
+
+// 'EmulatorBackend.contractValue' function
+
+const emulatorBackendContractValueFunctionName = "contractValue"
+
+const emulatorBackendContractValueFunctionDocString = `
+Returns the value of the given member of the contract with the given name,
+deployed to the account with the given address, or nil if the contract has no such member.
+Fails if no such contract is deployed to the account,
+or if the name or the member is not an identifier.
+`
+
+var emulatorBackendContractValueFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendContractValueFunctionName,
+)
+
+func emulatorBackendContractValueFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendContractValueFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			address, ok := invocation.Arguments[0].(interpreter.AddressValue)
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
 
-			mapping := make(map[string]common.Address, addresses.Count())
+			name, ok := invocation.Arguments[1].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
 
-			addresses.Iterate(nil, func(locationValue, addressValue interpreter.Value) bool {
-				location, ok := locationValue.(*interpreter.StringValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
+			member, ok := invocation.Arguments[2].(*interpreter.StringValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
 
-				address, ok := addressValue.(interpreter.AddressValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
+			value, err := readContractValue(
+				testFramework,
+				invocation.Interpreter,
+				common.Address(address),
+				name.Str,
+				member.Str,
+			)
+			if err != nil {
+				if goErrors.As(err, &ContractMemberNotFoundError{}) {
+					return interpreter.Nil
 				}
 
-				mapping[location.Str] = common.Address(address)
+				panic(err)
+			}
 
-				return true
-			})
+			return value
+		},
+	)
+}
 
-			testFramework.UseConfiguration(&Configuration{
-				Addresses: mapping,
-			})
+// 'EmulatorBackend.pendingTransactionCount' function
 
-			return interpreter.Void
+const emulatorBackendPendingTransactionCountFunctionName = "pendingTransactionCount"
+
+const emulatorBackendPendingTransactionCountFunctionDocString = `
+Returns the number of transactions added to the current block which were not executed yet.
+`
+
+var emulatorBackendPendingTransactionCountFunctionType = interfaceFunctionType(
+	blockchainBackendInterfaceType,
+	emulatorBackendPendingTransactionCountFunctionName,
+)
+
+func emulatorBackendPendingTransactionCountFunction(testFramework TestFramework) *interpreter.HostFunctionValue {
+	return interpreter.NewUnmeteredHostFunctionValue(
+		emulatorBackendPendingTransactionCountFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			framework := testFrameworkExtension[PendingTransactionsTestFramework](
+				testFramework,
+				emulatorBackendPendingTransactionCountFunctionName,
+			)
+
+			count := framework.PendingTransactionCount()
+
+			return interpreter.NewIntValueFromInt64(
+				invocation.Interpreter,
+				int64(count),
+			)
 		},
 	)
 }
 
+// readContractValue reads the given member of the given contract
+// by executing a generated script.
+// The name of the contract and the name of the member must be identifiers.
+func readContractValue(
+	testFramework TestFramework,
+	inter *interpreter.Interpreter,
+	address common.Address,
+	name string,
+	member string,
+) (interpreter.Value, error) {
+
+	// The names are part of the generated script, so they must be valid identifiers
+
+	for _, identifier := range []string{name, member} {
+		if !isIdentifier(inter, identifier) {
+			return nil, InvalidIdentifierError{
+				Identifier: identifier,
+			}
+		}
+	}
+
+	framework := testFrameworkExtension[ContractCodeTestFramework](
+		testFramework,
+		emulatorBackendContractValueFunctionName,
+	)
+
+	_, found, err := framework.ContractCode(address, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ContractNotDeployedError{
+			Address: address,
+			Name:    name,
+		}
+	}
+
+	script := fmt.Sprintf(
+		"import %[1]s from %[2]s\npub fun main(): AnyStruct? { return %[1]s.%[3]s }",
+		name,
+		address.HexWithPrefix(),
+		member,
+	)
+
+	result := testFramework.RunScript(inter, script, nil)
+	if result.Error != nil {
+		if isNotDeclaredMemberError(result.Error) {
+			return nil, ContractMemberNotFoundError{
+				Address:      address,
+				ContractName: name,
+				Name:         member,
+			}
+		}
+
+		return nil, result.Error
+	}
+
+	return result.Value, nil
+}
+
+// isIdentifier returns true if the given string is exactly one identifier
+func isIdentifier(memoryGauge common.MemoryGauge, identifier string) bool {
+	expression, errs := parser.ParseExpression(memoryGauge, []byte(identifier), parser.Config{})
+	if len(errs) > 0 {
+		return false
+	}
+
+	identifierExpression, ok := expression.(*ast.IdentifierExpression)
+	return ok && identifierExpression.Identifier.Identifier == identifier
+}
+
+// isNotDeclaredMemberError returns true if the given error,
+// or one of its wrapped or child errors, is a missing member error.
+func isNotDeclaredMemberError(err error) bool {
+	var notDeclaredErr *sema.NotDeclaredMemberError
+	if goErrors.As(err, &notDeclaredErr) {
+		return true
+	}
+
+	// The checker reports its errors as the child errors of a parent error,
+	// which goErrors.As does not reach
+
+	var parentErr errors.ParentError
+	if goErrors.As(err, &parentErr) {
+		for _, childErr := range parentErr.ChildErrors() {
+			if isNotDeclaredMemberError(childErr) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // TestFailedError
 
 type TestFailedError struct {
@@ -1502,6 +4712,134 @@ func (e TestFailedError) Error() string {
 	return fmt.Sprintf("test failed: %s", e.Err.Error())
 }
 
+// UnsupportedTestFrameworkFeatureError is reported when calling a function
+// which requires an optional extension of the TestFramework interface,
+// and the test provider does not implement it.
+type UnsupportedTestFrameworkFeatureError struct {
+	FunctionName string
+}
+
+var _ errors.UserError = UnsupportedTestFrameworkFeatureError{}
+
+func (UnsupportedTestFrameworkFeatureError) IsUserError() {}
+
+func (e UnsupportedTestFrameworkFeatureError) Error() string {
+	return fmt.Sprintf(
+		"unsupported test framework feature: the test provider does not support `%s`",
+		e.FunctionName,
+	)
+}
+
+// testFrameworkExtension returns the given test framework as the optional extension T,
+// which is required by the function with the given name.
+// It panics with an UnsupportedTestFrameworkFeatureError
+// if the test framework does not implement the extension.
+func testFrameworkExtension[T any](testFramework TestFramework, functionName string) T {
+	framework, ok := testFramework.(T)
+	if !ok {
+		panic(UnsupportedTestFrameworkFeatureError{
+			FunctionName: functionName,
+		})
+	}
+	return framework
+}
+
+// ContractNotDeployedError is reported when reading a member of a contract
+// which is not deployed to the account.
+type ContractNotDeployedError struct {
+	Address common.Address
+	Name    string
+}
+
+var _ errors.UserError = ContractNotDeployedError{}
+
+func (ContractNotDeployedError) IsUserError() {}
+
+func (e ContractNotDeployedError) Error() string {
+	return fmt.Sprintf(
+		"no such contract: contract `%s` is not deployed to account %s",
+		e.Name,
+		e.Address.HexWithPrefix(),
+	)
+}
+
+// ContractMemberNotFoundError is reported when reading a member of a contract
+// which has no such member.
+type ContractMemberNotFoundError struct {
+	Address      common.Address
+	ContractName string
+	Name         string
+}
+
+var _ errors.UserError = ContractMemberNotFoundError{}
+
+func (ContractMemberNotFoundError) IsUserError() {}
+
+func (e ContractMemberNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"no such member: contract `%s` deployed to account %s has no member `%s`",
+		e.ContractName,
+		e.Address.HexWithPrefix(),
+		e.Name,
+	)
+}
+
+// ContractParsingError is reported when the code of a contract cannot be parsed.
+type ContractParsingError struct {
+	Name string
+	Err  error
+}
+
+var _ errors.UserError = ContractParsingError{}
+
+func (ContractParsingError) IsUserError() {}
+
+func (e ContractParsingError) Unwrap() error {
+	return e.Err
+}
+
+func (e ContractParsingError) Error() string {
+	return fmt.Sprintf(
+		"cannot parse contract `%s`: %s",
+		e.Name,
+		e.Err.Error(),
+	)
+}
+
+// ContractNotDeclaredError is reported when the code of a contract
+// does not declare a contract with the expected name.
+type ContractNotDeclaredError struct {
+	Name string
+}
+
+var _ errors.UserError = ContractNotDeclaredError{}
+
+func (ContractNotDeclaredError) IsUserError() {}
+
+func (e ContractNotDeclaredError) Error() string {
+	return fmt.Sprintf(
+		"no such contract: code does not declare contract `%s`",
+		e.Name,
+	)
+}
+
+// InvalidIdentifierError is reported when reading a member of a contract,
+// and the name of the contract or the name of the member is not an identifier.
+type InvalidIdentifierError struct {
+	Identifier string
+}
+
+var _ errors.UserError = InvalidIdentifierError{}
+
+func (InvalidIdentifierError) IsUserError() {}
+
+func (e InvalidIdentifierError) Error() string {
+	return fmt.Sprintf(
+		"invalid identifier: `%s`",
+		e.Identifier,
+	)
+}
+
 func newMatcherWithGenericTestFunction(
 	invocation interpreter.Invocation,
 	testFunc interpreter.FunctionValue,
@@ -1576,6 +4914,67 @@ func newMatcherWithGenericTestFunction(
 	return matcher
 }
 
+// matcherCheck checks whether the given value is a match.
+// If it is not, it also returns a message describing why.
+type matcherCheck func(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) (
+	matches bool,
+	message string,
+)
+
+// newMatcherWithFailureMessage returns a matcher which tests values using the given check,
+// and which has a failure message that reports the check's message.
+func newMatcherWithFailureMessage(
+	invocation interpreter.Invocation,
+	check matcherCheck,
+) interpreter.Value {
+
+	testFunc := interpreter.NewHostFunctionValue(
+		nil,
+		matcherTestFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			matches, _ := check(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				invocation.Arguments[0],
+			)
+			return interpreter.AsBoolValue(matches)
+		},
+	)
+
+	failureMessageFunc := interpreter.NewHostFunctionValue(
+		nil,
+		matcherFailureMessageFunctionType,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			_, message := check(
+				invocation.Interpreter,
+				invocation.LocationRange,
+				invocation.Arguments[0],
+			)
+			return interpreter.NewUnmeteredStringValue(message)
+		},
+	)
+
+	inter := invocation.Interpreter
+
+	matcher, ok := newMatcherWithGenericTestFunction(invocation, testFunc).(*interpreter.CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	matcher.SetMember(
+		inter,
+		invocation.LocationRange,
+		matcherFailureMessageFieldName,
+		interpreter.NewUnmeteredSomeValueNonCopying(failureMessageFunc),
+	)
+
+	return matcher
+}
+
 func TestCheckerContractValueHandler(
 	checker *sema.Checker,
 	declaration *ast.CompositeDeclaration,
@@ -1634,7 +5033,13 @@ func NewTestInterpreterContractValueHandler(
 		default:
 			// During tests, imported contracts can be constructed using the constructor,
 			// similar to structs. Therefore, generate a constructor function.
-			return constructorGenerator(common.ZeroAddress)
+			// Contracts imported from an address location are constructed
+			// at that address, so they behave as if deployed there.
+			var address common.Address
+			if addressLocation, ok := compositeType.Location.(common.AddressLocation); ok {
+				address = addressLocation.Address
+			}
+			return constructorGenerator(address)
 		}
 	}
 }
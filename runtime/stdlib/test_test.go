@@ -20,10 +20,14 @@ package stdlib
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
@@ -35,6 +39,14 @@ import (
 )
 
 func newTestContractInterpreter(t *testing.T, code string) (*interpreter.Interpreter, error) {
+	return newTestContractInterpreterWithTestFramework(t, code, nil)
+}
+
+func newTestContractInterpreterWithTestFramework(
+	t *testing.T,
+	code string,
+	testFramework TestFramework,
+) (*interpreter.Interpreter, error) {
 	program, err := parser.ParseProgram(
 		nil,
 		[]byte(code),
@@ -97,7 +109,7 @@ func newTestContractInterpreter(t *testing.T, code string) (*interpreter.Interpr
 
 				return nil
 			},
-			ContractValueHandler: NewTestInterpreterContractValueHandler(nil),
+			ContractValueHandler: NewTestInterpreterContractValueHandler(testFramework),
 			UUIDHandler: func() (uint64, error) {
 				uuid++
 				return uuid, nil
@@ -112,6 +124,40 @@ func newTestContractInterpreter(t *testing.T, code string) (*interpreter.Interpr
 	return inter, nil
 }
 
+// testExpectFailureMessages runs each given statement, e.g. a failing 'Test.expect',
+// and requires it to fail with the corresponding assertion message.
+func testExpectFailureMessages(t *testing.T, messages map[string]string) {
+	for statement, message := range messages {
+		statement := statement
+		message := message
+
+		t.Run(statement, func(t *testing.T) {
+			t.Parallel()
+
+			script := fmt.Sprintf(
+				`
+                  import Test
+
+                  pub fun test() {
+                      %s
+                  }
+                `,
+				statement,
+			)
+
+			inter, err := newTestContractInterpreter(t, script)
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.Error(t, err)
+
+			var assertionErr AssertionError
+			require.ErrorAs(t, err, &assertionErr)
+			assert.Equal(t, message, assertionErr.Message)
+		})
+	}
+}
+
 func TestTestNewMatcher(t *testing.T) {
 	t.Parallel()
 
@@ -596,165 +642,5089 @@ func TestTestEqualMatcher(t *testing.T) {
 	})
 }
 
-func TestTestExpect(t *testing.T) {
+func TestTestBeCloseToMatcher(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect(1.1, Test.beCloseTo(1.0, delta: 0.01))`: "1.10000000 is not within 0.01000000 of 1.00000000",
+			`Test.expect(1, Test.beCloseTo(1.0, delta: 0.01))`:   "expected a value of type `UFix64`, got `Int`",
+		})
+	})
+
+	t.Run("within delta", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               Test.expect("this string", Test.equal("this string"))
+           pub fun test(): Bool {
+               let matcher = Test.beCloseTo(1.0, delta: 0.01)
+               return matcher.test(1.005) && matcher.test(0.995)
            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("test")
+		result, err := inter.Invoke("test")
 		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
 	})
 
-	t.Run("fail", func(t *testing.T) {
+	t.Run("exactly delta", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               Test.expect("this string", Test.equal("other string"))
+           pub fun test(): Bool {
+               let matcher = Test.beCloseTo(0.0, delta: 0.00000001)
+               return matcher.test(0.00000001)
            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("test")
-		require.Error(t, err)
-		assert.ErrorAs(t, err, &AssertionError{})
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
 	})
 
-	t.Run("different types", func(t *testing.T) {
+	t.Run("outside delta", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               Test.expect("string", Test.equal(1))
+           pub fun test(): Bool {
+               let matcher = Test.beCloseTo(1.0, delta: 0.01)
+               return matcher.test(1.02) || matcher.test(0.98)
            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("test")
-		require.Error(t, err)
-		assert.ErrorAs(t, err, &AssertionError{})
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("with explicit types", func(t *testing.T) {
+	t.Run("max value", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               Test.expect<String>("hello", Test.equal("hello"))
+           pub fun test(): Bool {
+               let matcher = Test.beCloseTo(UFix64.max, delta: 1.0)
+               return matcher.test(UFix64.max - 1.0) && !matcher.test(0.0)
            }
         `
 
 		inter, err := newTestContractInterpreter(t, script)
 		require.NoError(t, err)
 
-		_, err = inter.Invoke("test")
+		result, err := inter.Invoke("test")
 		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
 	})
 
-	t.Run("mismatching types", func(t *testing.T) {
+	t.Run("different type", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               Test.expect<Int>("string", Test.equal(1))
+           pub fun test(): Bool {
+               let matcher = Test.beCloseTo(1.0, delta: 0.01)
+               return matcher.test(1)
            }
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		errs := checker.RequireCheckerErrors(t, err, 2)
-		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
 	})
 
-	t.Run("resource with resource matcher", func(t *testing.T) {
+	t.Run("with expect", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
            pub fun test() {
-               let f1 <- create Foo()
-               let f2 <- create Foo()
-               Test.expect(<-f1, Test.equal(<-f2))
+               Test.expect(0.3, Test.beCloseTo(0.1 + 0.2, delta: 0.0))
+               Test.expect(1.1, Test.beCloseTo(1.0, delta: 0.01))
            }
-
-           pub resource Foo {}
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		errs := checker.RequireCheckerErrors(t, err, 2)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
 	})
+}
 
-	t.Run("resource with struct matcher", func(t *testing.T) {
+func TestTestBeArithmeticErrorMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test(): Bool {
+            let blockchain = Test.newEmulatorBlockchain()
+            let scriptResult = blockchain.executeScript("pub fun main() {}", [])
+            return Test.beArithmeticError().test(scriptResult)
+        }
+    `
+
+	test := func(t *testing.T, scriptErr error, expected bool) {
+		testFramework := &mockedTestFramework{
+			runScript: func(_ *interpreter.Interpreter, _ string, _ []interpreter.Value) *ScriptResult {
+				return &ScriptResult{
+					Value: interpreter.Void,
+					Error: scriptErr,
+				}
+			},
+		}
+
+		inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.AsBoolValue(expected), result)
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, fmt.Errorf("execution failed: %w", interpreter.OverflowError{}), true)
+	})
+
+	t.Run("underflow", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, fmt.Errorf("execution failed: %w", interpreter.UnderflowError{}), true)
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			t,
+			fmt.Errorf(
+				"execution failed: %w",
+				interpreter.ConversionError{
+					Err:        interpreter.OverflowError{},
+					SourceType: interpreter.PrimitiveStaticTypeInt,
+					TargetType: interpreter.PrimitiveStaticTypeUInt8,
+				},
+			),
+			true,
+		)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, fmt.Errorf("execution failed: %w", interpreter.DivisionByZeroError{}), false)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, nil, false)
+	})
+
+	t.Run("not a result", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
-           pub fun test() {
-               let foo <- create Foo()
-               let bar = Bar()
-               Test.expect(<-foo, Test.equal(bar))
+           pub fun test(): Bool {
+               return Test.beArithmeticError().test(Test.newError("overflow", kind: Test.ErrorKind.arithmetic, stackTrace: []))
            }
+        `
 
-           pub resource Foo {}
-           pub struct Bar {}
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.FalseValue, result)
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test(): String {
+                let blockchain = Test.newEmulatorBlockchain()
+                let scriptResult = blockchain.executeScript("pub fun main() {}", [])
+                return Test.beArithmeticError().failureMessage!(scriptResult)
+            }
+
+            pub fun testNotResult(): String {
+                return Test.beArithmeticError().failureMessage!(1)
+            }
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		test := func(t *testing.T, scriptErr error, expected string) {
+			testFramework := &mockedTestFramework{
+				runScript: func(_ *interpreter.Interpreter, _ string, _ []interpreter.Value) *ScriptResult {
+					return &ScriptResult{
+						Value: interpreter.Void,
+						Error: scriptErr,
+					}
+				},
+			}
 
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+			result, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+			require.NoError(t, err)
+			assert.Equal(t, interpreter.NewUnmeteredStringValue(expected), result)
+		}
+
+		test(
+			t,
+			fmt.Errorf("execution failed: %w", interpreter.DivisionByZeroError{}),
+			"expected an arithmetic error, got generic error: execution failed: division by zero",
+		)
+
+		test(t, nil, "expected an arithmetic error, but the result has no error")
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("testNotResult")
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			interpreter.NewUnmeteredStringValue("expected a transaction result or a script result, got `Int`"),
+			result,
+		)
 	})
+}
 
-	t.Run("struct with resource matcher", func(t *testing.T) {
+func TestTestBeAuthorizerMismatchMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test(): Bool {
+            let blockchain = Test.newEmulatorBlockchain()
+            let account = blockchain.createAccount()
+            let tx = Test.Transaction(
+                code: "transaction { prepare(signer: AuthAccount) {} }",
+                authorizers: [],
+                signers: [account],
+                arguments: [],
+            )
+            let txResult = blockchain.executeTransaction(tx)
+            return Test.beAuthorizerMismatch().test(txResult)
+        }
+
+        pub fun testFailureMessage(): String {
+            let blockchain = Test.newEmulatorBlockchain()
+            let account = blockchain.createAccount()
+            let tx = Test.Transaction(
+                code: "transaction { prepare(signer: AuthAccount) {} }",
+                authorizers: [],
+                signers: [account],
+                arguments: [],
+            )
+            let txResult = blockchain.executeTransaction(tx)
+            return Test.beAuthorizerMismatch().failureMessage!(txResult)
+        }
+
+        pub fun testNotResultFailureMessage(): String {
+            return Test.beAuthorizerMismatch().failureMessage!(1)
+        }
+    `
+
+	newTestFramework := func(result *TransactionResult) *mockedTestFramework {
+		return &mockedTestFramework{
+			createAccount: func() (*Account, error) {
+				return &Account{
+					PublicKey: &PublicKey{
+						PublicKey: []byte{1, 2, 3},
+						SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+					},
+					Address: common.Address{0x1},
+				}, nil
+			},
+			stdlibHandler: func() StandardLibraryHandler {
+				return nil
+			},
+			addTransaction: func(
+				_ *interpreter.Interpreter,
+				_ string,
+				_ []common.Address,
+				_ []*Account,
+				_ []interpreter.Value,
+				_ *TransactionExpiry,
+			) error {
+				return nil
+			},
+			executeTransaction: func() *TransactionResult {
+				return result
+			},
+			commitBlock: func() error {
+				return nil
+			},
+		}
+	}
+
+	test := func(t *testing.T, result *TransactionResult, expected bool) {
+		testFramework := newTestFramework(result)
+
+		value, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.AsBoolValue(expected), value)
+	}
+
+	t.Run("authorizer mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			t,
+			&TransactionResult{
+				Error:              errors.New("authorizer count mismatch for transaction: expected 1, got 0"),
+				AuthorizerMismatch: true,
+			},
+			true,
+		)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		t.Parallel()
+
+		test(
+			t,
+			&TransactionResult{
+				Error: fmt.Errorf("execution failed: %w", interpreter.OverflowError{}),
+			},
+			false,
+		)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, &TransactionResult{}, false)
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testFailureMessage := func(t *testing.T, result *TransactionResult, functionName string, expected string) {
+			inter, err := newTestContractInterpreterWithTestFramework(t, script, newTestFramework(result))
+			require.NoError(t, err)
+
+			value, err := inter.Invoke(functionName)
+			require.NoError(t, err)
+			assert.Equal(t, interpreter.NewUnmeteredStringValue(expected), value)
+		}
+
+		testFailureMessage(
+			t,
+			&TransactionResult{
+				Error: fmt.Errorf("execution failed: %w", interpreter.OverflowError{}),
+			},
+			"testFailureMessage",
+			"expected an authorizer mismatch, got arithmetic error: execution failed: overflow",
+		)
+
+		testFailureMessage(
+			t,
+			&TransactionResult{},
+			"testFailureMessage",
+			"expected an authorizer mismatch, but the transaction succeeded",
+		)
+
+		testFailureMessage(
+			t,
+			&TransactionResult{},
+			"testNotResultFailureMessage",
+			"expected a transaction result, got `Int`",
+		)
+	})
+}
+
+func TestTestNewError(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("initializer", func(t *testing.T) {
 		t.Parallel()
 
 		script := `
            import Test
 
            pub fun test() {
-               let foo = Foo()
-               let bar <- create Bar()
-               Test.expect(foo, Test.equal(<-bar))
+               let error = Test.Error("failed")
+               Test.assert(error.message == "failed")
+               Test.assert(error.kind == Test.ErrorKind.generic)
+               Test.assert(error.stackTrace.length == 0)
            }
+        `
 
-           pub struct Foo {}
-           pub resource Bar {}
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("newError", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let error = Test.newError(
+                   "overflow",
+                   kind: Test.ErrorKind.arithmetic,
+                   stackTrace: ["0000000000000001.Foo:12:8"]
+               )
+               Test.assert(error.message == "overflow")
+               Test.assert(error.kind == Test.ErrorKind.arithmetic)
+               Test.assert(error.stackTrace == ["0000000000000001.Foo:12:8"])
+           }
         `
 
-		_, err := newTestContractInterpreter(t, script)
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
 
-		errs := checker.RequireCheckerErrors(t, err, 1)
-		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+}
+
+func TestTestErrorStackTrace(t *testing.T) {
+
+	t.Parallel()
+
+	// Produce an interpreter error which occurs in nested function calls
+
+	failingInter, err := newTestContractInterpreter(t, `
+        pub fun main() {
+            a()
+        }
+
+        pub fun a() {
+            b()
+        }
+
+        pub fun b(): UInt8 {
+            let x: UInt8 = 255
+            return x + 1
+        }
+    `)
+	require.NoError(t, err)
+
+	_, scriptErr := failingInter.Invoke("main")
+	require.Error(t, scriptErr)
+
+	const script = `
+        import Test
+
+        pub fun test(): [String] {
+            let blockchain = Test.newEmulatorBlockchain()
+            let scriptResult = blockchain.executeScript("pub fun main() {}", [])
+            return scriptResult.error!.stackTrace
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		runScript: func(_ *interpreter.Interpreter, _ string, _ []interpreter.Value) *ScriptResult {
+			return &ScriptResult{
+				Error: scriptErr,
+			}
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	frames, err := arrayValueToSlice(result)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]interpreter.Value{
+			interpreter.NewUnmeteredStringValue("test:3:12"),
+			interpreter.NewUnmeteredStringValue("test:7:12"),
+		},
+		frames,
+	)
+}
+
+func TestTestConversionErrorKind(t *testing.T) {
+
+	t.Parallel()
+
+	// Produce an interpreter error which is caused by an overflowing conversion
+
+	failingInter, err := newTestContractInterpreter(t, `
+        pub fun main(): UInt8 {
+            let x: Int = 256
+            return UInt8(x)
+        }
+    `)
+	require.NoError(t, err)
+
+	_, scriptErr := failingInter.Invoke("main")
+	require.Error(t, scriptErr)
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let scriptResult = blockchain.executeScript("pub fun main() {}", [])
+            let error = scriptResult.error!
+            Test.assert(error.kind == Test.ErrorKind.conversion)
+            Test.assert(Test.beArithmeticError().test(scriptResult))
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		runScript: func(_ *interpreter.Interpreter, _ string, _ []interpreter.Value) *ScriptResult {
+			return &ScriptResult{
+				Error: scriptErr,
+			}
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, scriptErr, "cannot convert value of type `Int` to type `UInt8`: overflow")
+}
+
+func TestTestPostConditionErrorKind(t *testing.T) {
+
+	t.Parallel()
+
+	// Produce an interpreter error which is caused by a failed post-condition using before
+
+	failingInter, err := newTestContractInterpreter(t, `
+        pub var x = 0
+
+        pub fun main() {
+            post {
+                x == before(x) + 2
+            }
+            x = x + 1
+        }
+    `)
+	require.NoError(t, err)
+
+	_, transactionErr := failingInter.Invoke("main")
+	require.Error(t, transactionErr)
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+            let txResult = blockchain.executeNextTransaction()!
+            Test.assert(txResult.error!.kind == Test.ErrorKind.postCondition)
+        }
+    `
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				Error: transactionErr,
+			}
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestTestHaveBorrowTypeMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			getCapability: func(address common.Address, path interpreter.PathValue) (*interpreter.StorageCapabilityValue, error) {
+				// Capabilities of account 0x2 have no borrow type
+				var borrowType interpreter.StaticType
+				if address == common.MustBytesToAddress([]byte{0x1}) {
+					borrowType = interpreter.ReferenceStaticType{
+						BorrowedType: interpreter.PrimitiveStaticTypeInt,
+					}
+				}
+
+				return interpreter.NewUnmeteredStorageCapabilityValue(
+					interpreter.AddressValue(address),
+					path,
+					borrowType,
+				), nil
+			},
+		}
+	}
+
+	t.Run("borrow type", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let capability = blockchain.getCapability(address: 0x1, path: /public/foo)!
+
+                Test.expect(capability, Test.haveBorrowType(Type<&Int>()))
+                Test.assert(!Test.haveBorrowType(Type<&String>()).test(capability))
+                Test.assert(!Test.haveBorrowType(Type<Int>()).test(1))
+            }
+        `
+
+		_, err := invokeTestFunctionWithTestFramework(t, script, newTestFramework())
+		require.NoError(t, err)
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		messages := map[string]string{
+			`Test.expect(capability, Test.haveBorrowType(Type<&String>()))`: "expected borrow type `&String`, got `&Int`",
+			`Test.expect(noBorrowType, Test.haveBorrowType(Type<&Int>()))`:  "capability has no borrow type",
+			`Test.expect(1, Test.haveBorrowType(Type<&Int>()))`:             "expected a capability, got `Int`",
+			`Test.expect(nil, Test.haveBorrowType(Type<&Int>()))`:           "expected a capability, got nil",
+		}
+
+		for statement, message := range messages {
+			statement := statement
+			message := message
+
+			t.Run(statement, func(t *testing.T) {
+				t.Parallel()
+
+				script := fmt.Sprintf(
+					`
+                      import Test
+
+                      pub fun test() {
+                          let blockchain = Test.newEmulatorBlockchain()
+                          let capability = blockchain.getCapability(address: 0x1, path: /public/foo)!
+                          let noBorrowType = blockchain.getCapability(address: 0x2, path: /public/foo)!
+
+                          %s
+                      }
+                    `,
+					statement,
+				)
+
+				_, err := invokeTestFunctionWithTestFramework(t, script, newTestFramework())
+				require.Error(t, err)
+
+				var assertionErr AssertionError
+				require.ErrorAs(t, err, &assertionErr)
+				assert.Equal(t, message, assertionErr.Message)
+			})
+		}
+	})
+}
+
+func TestTestBeSomeMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("some", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let value: Int? = 1
+               Test.expect(value, Test.beSome())
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let value: Int? = nil
+               Test.expect(value, Test.beSome())
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`let value: Int? = nil; Test.expect(value, Test.beSome())`: "expected a value, got nil",
+		})
+	})
+}
+
+func TestTestEqualDictionaryMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect({"a": 1, "b": 2}, Test.equalDictionary({"a": 1}))`:         "dictionaries differ: expected length 1, got 2; extra keys: \"b\"",
+			`Test.expect({"a": 1}, Test.equalDictionary({"a": 1, "b": 2}))`:         "dictionaries differ: expected length 2, got 1; missing keys: \"b\"",
+			`Test.expect({"a": 1, "b": 3}, Test.equalDictionary({"a": 1, "b": 2}))`: "dictionaries differ: mismatched values: \"b\" (expected 2, got 3)",
+			`Test.expect({"a": 1, "c": 3}, Test.equalDictionary({"a": 2, "b": 2}))`: "dictionaries differ: missing keys: \"b\"; extra keys: \"c\"; mismatched values: \"a\" (expected 2, got 1)",
+			`Test.expect([1], Test.equalDictionary({"a": 1}))`:                      "expected a dictionary, got `[Int]`",
+		})
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let value: {String: Int} = {"a": 1, "b": 2, "c": 3}
+               let expected: {String: AnyStruct} = {"c": 3, "a": 1, "b": 2}
+               Test.expect(value, Test.equalDictionary(expected))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("different", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun testMissing() {
+               Test.expect({"a": 1}, Test.equalDictionary({"a": 1, "b": 2}))
+           }
+
+           pub fun testExtra() {
+               Test.expect({"a": 1, "b": 2}, Test.equalDictionary({"a": 1}))
+           }
+
+           pub fun testDifferentKey() {
+               Test.expect({"a": 1, "c": 2}, Test.equalDictionary({"a": 1, "b": 2}))
+           }
+
+           pub fun testDifferentValue() {
+               Test.expect({"a": 1, "b": 3}, Test.equalDictionary({"a": 1, "b": 2}))
+           }
+
+           pub fun testNotDictionary() {
+               Test.expect([1, 2], Test.equalDictionary({"a": 1, "b": 2}))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		for _, name := range []string{
+			"testMissing",
+			"testExtra",
+			"testDifferentKey",
+			"testDifferentValue",
+			"testNotDictionary",
+		} {
+			_, err = inter.Invoke(name)
+			require.Error(t, err, name)
+			assert.ErrorAs(t, err, &AssertionError{}, name)
+		}
+	})
+}
+
+func TestTestExpectJSON(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+       import Test
+
+       pub fun testMatch() {
+           Test.expectJSON(42, "match.json")
+       }
+
+       pub fun testMismatch() {
+           Test.expectJSON(43, "match.json")
+       }
+
+       pub fun testUpdate() {
+           Test.expectJSON(44, "update.json")
+       }
+    `
+
+	updatedFiles := map[string]string{}
+
+	testFramework := &mockedTestFramework{
+		encodeJSON: func(_ *interpreter.Interpreter, value interpreter.Value) ([]byte, error) {
+			return []byte(fmt.Sprintf(`{"type":"Int","value":"%s"}`, value)), nil
+		},
+		updateGoldenFile: func(path string, content []byte) (bool, error) {
+			if path != "update.json" {
+				return false, nil
+			}
+			updatedFiles[path] = string(content)
+			return true, nil
+		},
+		readFile: func(path string) (string, error) {
+			return "{\"type\":\"Int\",\"value\":\"42\"}\n", nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testMatch")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testMismatch")
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &AssertionError{})
+	assert.ErrorContains(t, err, `value does not match golden file match.json`)
+
+	_, err = inter.Invoke("testUpdate")
+	require.NoError(t, err)
+	assert.Equal(t,
+		map[string]string{"update.json": `{"type":"Int","value":"44"}`},
+		updatedFiles,
+	)
+}
+
+func TestTestPathFromString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let paths: [Path] = [
+                   StoragePath(identifier: "foo")!,
+                   PrivatePath(identifier: "foo")!,
+                   PublicPath(identifier: "foo")!
+               ]
+
+               for path in paths {
+                   Test.assert(Test.pathFromString(path.toString()) == path)
+               }
+
+               Test.assert(Test.pathFromString("/storage/foo")! as! StoragePath == /storage/foo)
+               Test.assert(Test.pathFromString("/private/foo")! as! PrivatePath == /private/foo)
+               Test.assert(Test.pathFromString("/public/foo")! as! PublicPath == /public/foo)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               for path in [
+                   "",
+                   "/",
+                   "storage/foo",
+                   "/storage",
+                   "/storage/",
+                   "/storage/foo/bar",
+                   "/storage/1foo",
+                   "/unknown/foo"
+               ] {
+                   Test.assert(Test.pathFromString(path) == nil, message: path)
+               }
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+}
+
+func TestTestExpectNoStateChange(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+       import Test
+
+       pub fun testFailed() {
+           let blockchain = Test.newEmulatorBlockchain()
+           Test.expectNoStateChange(fun () {
+               let result = blockchain.executeNextTransaction()!
+               Test.assert(result.status == Test.ResultStatus.failed)
+           })
+       }
+
+       pub fun testSucceeded() {
+           let blockchain = Test.newEmulatorBlockchain()
+           Test.expectNoStateChange(fun () {
+               let result = blockchain.executeNextTransaction()!
+               Test.assert(result.status == Test.ResultStatus.succeeded)
+           })
+       }
+    `
+
+	var state byte
+	var executed int
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			executed++
+
+			// The first transaction fails and is reverted,
+			// the second transaction succeeds and changes the state
+			if executed == 1 {
+				return &TransactionResult{Error: errors.New("failed")}
+			}
+			state++
+			return &TransactionResult{}
+		},
+		stateCommitment: func() ([]byte, error) {
+			return []byte{state}, nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testFailed")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testSucceeded")
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &AssertionError{})
+	assert.ErrorContains(t, err, "state of the blockchain changed")
+}
+
+func TestTestHaveLengthMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect([1, 2], Test.haveLength(3))`:   "expected length 3, got 2",
+			`Test.expect({"a": 1}, Test.haveLength(0))`: "expected length 0, got 1",
+			`Test.expect("abc", Test.haveLength(2))`:    "expected length 2, got 3",
+			`Test.expect(1, Test.haveLength(1))`:        "expected an array, a dictionary, or a string, got `Int`",
+		})
+	})
+
+	t.Run("matching length", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect([1, 2, 3], Test.haveLength(3))
+               Test.expect({"a": 1, "b": 2}, Test.haveLength(2))
+               Test.expect("abc", Test.haveLength(3))
+               Test.expect("", Test.haveLength(0))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("different length", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun testArray() {
+               Test.expect([1, 2, 3], Test.haveLength(2))
+           }
+
+           pub fun testDictionary() {
+               Test.expect({"a": 1}, Test.haveLength(2))
+           }
+
+           pub fun testString() {
+               Test.expect("abc", Test.haveLength(4))
+           }
+
+           pub fun testInt() {
+               Test.expect(3, Test.haveLength(3))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		for _, name := range []string{
+			"testArray",
+			"testDictionary",
+			"testString",
+			"testInt",
+		} {
+			_, err = inter.Invoke(name)
+			require.Error(t, err, name)
+			assert.ErrorAs(t, err, &AssertionError{}, name)
+		}
+	})
+}
+
+func TestTestConformToMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+       import Test
+
+       pub resource interface I {}
+
+       pub resource Foo: I {}
+
+       pub resource Bar {}
+
+       pub struct interface SI {}
+
+       pub struct S: SI {}
+
+       pub fun testConforming() {
+           Test.expect(Type<@Foo>(), Test.conformTo(Type<@AnyResource{I}>()))
+           Test.expect(Type<S>(), Test.conformTo(Type<AnyStruct{SI}>()))
+       }
+
+       pub fun testNonConforming() {
+           Test.expect(Type<@Bar>(), Test.conformTo(Type<@AnyResource{I}>()))
+       }
+
+       pub fun testNotType() {
+           Test.expect(S(), Test.conformTo(Type<AnyStruct{SI}>()))
+       }
+    `
+
+	inter, err := newTestContractInterpreter(t, script)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testConforming")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testNonConforming")
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &AssertionError{})
+
+	_, err = inter.Invoke("testNotType")
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &AssertionError{})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect(Type<Int>(), Test.conformTo(Type<String>()))`:                            "`Int` does not conform to `String`",
+			`Test.expect(Type<Int>(), Test.conformTo(Type<AnyStruct{Test.BlockchainBackend}>()))`: "`Int` does not conform to `AnyStruct{I.Test.Test.BlockchainBackend}`",
+			`Test.expect(1, Test.conformTo(Type<Int>()))`:                                         "expected a type, got `Int`",
+		})
+	})
+}
+
+func TestTestEnumRawValues(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let statuses = [Test.ResultStatus.succeeded, Test.ResultStatus.failed]
+            for status in statuses {
+                Test.assert(Test.ResultStatus(rawValue: status.rawValue) == status)
+            }
+            Test.assert(Test.ResultStatus(rawValue: 2) == nil)
+            Test.assert(Test.ResultStatus(rawValue: UInt8.max) == nil)
+
+            let executionStatuses = [
+                Test.ExecutionStatus.executed,
+                Test.ExecutionStatus.sealed,
+                Test.ExecutionStatus.expired
+            ]
+            for status in executionStatuses {
+                Test.assert(Test.ExecutionStatus(rawValue: status.rawValue) == status)
+            }
+            Test.assert(Test.ExecutionStatus(rawValue: 3) == nil)
+        }
+    `
+
+	inter, err := newTestContractInterpreter(t, script)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestTestBeInRangeMatcher(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, script string, expected bool) {
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.AsBoolValue(expected), result)
+	}
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect(0, Test.beInRange(1, 10))`:           "0 is less than the low bound 1",
+			`Test.expect(11, Test.beInRange(1, 10))`:          "11 is greater than the high bound 10",
+			`Test.expect(1, Test.beInExclusiveRange(1, 10))`:  "1 is not greater than the exclusive low bound 1",
+			`Test.expect(10, Test.beInExclusiveRange(1, 10))`: "10 is not less than the exclusive high bound 10",
+			`Test.expect(1 as UInt8, Test.beInRange(1, 10))`:  "expected a value of type `Int`, got `UInt8`",
+		})
+	})
+
+	t.Run("integer, inclusive", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInRange(1, 10)
+                  return matcher.test(1) && matcher.test(5) && matcher.test(10)
+              }
+            `,
+			true,
+		)
+	})
+
+	t.Run("integer, outside", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInRange(1, 10)
+                  return matcher.test(0) || matcher.test(11)
+              }
+            `,
+			false,
+		)
+	})
+
+	t.Run("integer, exclusive", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInExclusiveRange(1, 10)
+                  return matcher.test(2)
+                      && matcher.test(9)
+                      && !matcher.test(1)
+                      && !matcher.test(10)
+              }
+            `,
+			true,
+		)
+	})
+
+	t.Run("UInt64", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInRange(100 as UInt64, UInt64.max)
+                  return matcher.test(UInt64.max) && !matcher.test(99 as UInt64)
+              }
+            `,
+			true,
+		)
+	})
+
+	t.Run("UFix64", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInRange(0.5, 1.5)
+                  return matcher.test(0.5)
+                      && matcher.test(1.0)
+                      && matcher.test(1.5)
+                      && !matcher.test(1.50000001)
+              }
+            `,
+			true,
+		)
+	})
+
+	t.Run("different type", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+              import Test
+
+              pub fun test(): Bool {
+                  let matcher = Test.beInRange(1, 10)
+                  return matcher.test(5 as UInt8) || matcher.test("5")
+              }
+            `,
+			false,
+		)
+	})
+
+	t.Run("expect", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect(11, Test.beInRange(1, 10))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("bounds of different types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.beInRange(1, 10 as UInt8)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "bounds must have the same type: got `Int` and `UInt8`")
+	})
+
+	t.Run("low bound greater than high bound", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.beInRange(10, 1)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "low bound 10 is greater than high bound 1")
+	})
+}
+
+func TestTestUnwrap(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("some", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test(): Bool {
+               let value: AnyStruct? = 1
+               return Test.unwrap(value) as! Int == 1
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let value: AnyStruct? = nil
+               Test.unwrap(value)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, err, &assertionErr)
+		assert.Equal(t, "expected a value, but got nil", assertionErr.Message)
+	})
+}
+
+func TestTestExpect(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect("this string", Test.equal("this string"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect("this string", Test.equal("other string"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("failure messages", func(t *testing.T) {
+		t.Parallel()
+
+		testExpectFailureMessages(t, map[string]string{
+			`Test.expect(1, Test.equal(2))`: "",
+			`Test.expect(1, Test.equal(2).withFailureMessage(fun (value: AnyStruct): String {
+                 return "unexpected value"
+             }))`: "unexpected value",
+			`Test.expect(11, Test.beInRange(1, 5).and(Test.beInRange(0, 10)))`: "11 is greater than the high bound 5",
+			`Test.expect(2, Test.beInRange(1, 5).and(Test.beInRange(3, 10)))`:  "2 is less than the low bound 3",
+			`Test.expect(2, Test.beInRange(1, 5).and(Test.equal(3)))`:          "",
+			`Test.expect(2, Test.equal(3).and(Test.equal(4)))`:                 "",
+			`Test.expect(12, Test.beInRange(1, 5).or(Test.beInRange(0, 10)))`:  "12 is greater than the high bound 5, and 12 is greater than the high bound 10",
+			`Test.expect(12, Test.beInRange(1, 5).or(Test.equal(3)))`:          "12 is greater than the high bound 5",
+			`Test.expect(12, Test.equal(3).or(Test.beInRange(1, 5)))`:          "12 is greater than the high bound 5",
+			`Test.expect(12, Test.equal(3).or(Test.equal(4)))`:                 "",
+		})
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect("string", Test.equal(1))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &AssertionError{})
+	})
+
+	t.Run("with explicit types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect<String>("hello", Test.equal("hello"))
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("mismatching types", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               Test.expect<Int>("string", Test.equal(1))
+           }
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 2)
+		assert.IsType(t, &sema.TypeParameterTypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("resource with resource matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let f1 <- create Foo()
+               let f2 <- create Foo()
+               Test.expect(<-f1, Test.equal(<-f2))
+           }
+
+           pub resource Foo {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 2)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("resource with struct matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let foo <- create Foo()
+               let bar = Bar()
+               Test.expect(<-foo, Test.equal(bar))
+           }
+
+           pub resource Foo {}
+           pub struct Bar {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("struct with resource matcher", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let foo = Foo()
+               let bar <- create Bar()
+               Test.expect(foo, Test.equal(<-bar))
+           }
+
+           pub struct Foo {}
+           pub resource Bar {}
+        `
+
+		_, err := newTestContractInterpreter(t, script)
+
+		errs := checker.RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestTestExpectEventOrder(t *testing.T) {
+
+	t.Parallel()
+
+	const events = `
+        pub event Withdrawn(amount: UFix64)
+
+        pub event Deposited(amount: UFix64)
+
+        pub event Other()
+    `
+
+	newTestFramework := func(inter **interpreter.Interpreter, identifiers ...string) *mockedTestFramework {
+		return &mockedTestFramework{
+			executeTransaction: func() *TransactionResult {
+				var events []interpreter.Value
+				for _, identifier := range identifiers {
+					var fields []interpreter.CompositeField
+					if identifier != "Other" {
+						fields = []interpreter.CompositeField{
+							{
+								Name:  "amount",
+								Value: interpreter.NewUnmeteredUFix64ValueWithInteger(1, interpreter.EmptyLocationRange),
+							},
+						}
+					}
+
+					events = append(
+						events,
+						interpreter.NewCompositeValue(
+							*inter,
+							interpreter.EmptyLocationRange,
+							utils.TestLocation,
+							identifier,
+							common.CompositeKindEvent,
+							fields,
+							common.ZeroAddress,
+						),
+					)
+				}
+
+				return &TransactionResult{
+					Events: events,
+				}
+			},
+		}
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+        ` + events + `
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.expectEventOrder(result, [Type<Withdrawn>(), Type<Deposited>()])
+            }
+        `
+
+		var inter *interpreter.Interpreter
+		testFramework := newTestFramework(&inter, "Other", "Withdrawn", "Other", "Deposited")
+
+		var err error
+		inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+        ` + events + `
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.expectEventOrder(result, [Type<Withdrawn>(), Type<Deposited>()])
+            }
+        `
+
+		var inter *interpreter.Interpreter
+		testFramework := newTestFramework(&inter, "Deposited", "Withdrawn")
+
+		var err error
+		inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, err, &assertionErr)
+		assert.Equal(
+			t,
+			"event of type Deposited was not emitted in the expected order",
+			assertionErr.Message,
+		)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+            import Test
+        ` + events + `
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.expectEventOrder(result, [Type<Withdrawn>()])
+            }
+        `
+
+		var inter *interpreter.Interpreter
+		testFramework := newTestFramework(&inter, "Other")
+
+		var err error
+		inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.Error(t, err)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, err, &assertionErr)
+	})
+}
+
+func TestTestToTransactionArg(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test(): Int {
+               let scriptResult = Test.ScriptResult(
+                   status: Test.ResultStatus.succeeded,
+                   returnValue: 42,
+                   error: nil,
+                   events: []
+               )
+               let argument = Test.toTransactionArg(scriptResult)
+               return argument! as! Int
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewUnmeteredIntValueFromInt64(42), result)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test(): Bool {
+               let scriptResult = Test.ScriptResult(
+                   status: Test.ResultStatus.succeeded,
+                   returnValue: nil,
+                   error: nil,
+                   events: []
+               )
+               let argument = Test.toTransactionArg(scriptResult)
+               return argument == nil
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.TrueValue, result)
+	})
+
+	t.Run("failed script", func(t *testing.T) {
+		t.Parallel()
+
+		script := `
+           import Test
+
+           pub fun test() {
+               let scriptResult = Test.ScriptResult(
+                   status: Test.ResultStatus.failed,
+                   returnValue: nil,
+                   error: Test.Error("panic: failed"),
+                   events: []
+               )
+               Test.toTransactionArg(scriptResult)
+           }
+        `
+
+		inter, err := newTestContractInterpreter(t, script)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorContains(t, err, "cannot use the return value of a failed script: panic: failed")
+	})
+}
+
+// newExecuteAndCommitTestFramework returns a test framework which executes
+// the added transactions in order, and fails every second one.
+func newExecuteAndCommitTestFramework(t *testing.T) (
+	testFramework *mockedTestFramework,
+	executed *[]string,
+	committed *bool,
+) {
+	var pending []string
+	executed = &[]string{}
+	committed = new(bool)
+
+	testFramework = &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			code string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			pending = append(pending, code)
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			if len(pending) == 0 {
+				return nil
+			}
+			code := pending[0]
+			pending = pending[1:]
+			*executed = append(*executed, code)
+
+			if len(*executed)%2 == 0 {
+				return &TransactionResult{Error: errors.New("failed")}
+			}
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			require.Empty(t, pending)
+			*committed = true
+			return nil
+		},
+		pendingTransactionCount: func() int {
+			return len(pending)
+		},
+	}
+
+	return testFramework, executed, committed
+}
+
+// invokeTestFunctionWithTestFramework interprets the given test script
+// using the given test framework, e.g. a mocked test framework
+// which only implements the functions used by the script,
+// and invokes the script's function named 'test'.
+func invokeTestFunctionWithTestFramework(
+	t *testing.T,
+	script string,
+	testFramework TestFramework,
+) (interpreter.Value, error) {
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	return inter.Invoke("test")
+}
+
+// createTestAccount is a mocked implementation of TestFramework.CreateAccount,
+// which creates an account with the address 0x1.
+func createTestAccount() (*Account, error) {
+	return &Account{
+		PublicKey: &PublicKey{
+			PublicKey: []byte{1, 2, 3},
+			SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+		},
+		Address: common.Address{0x1},
+	}, nil
+}
+
+func TestBlockchainScriptReturnType(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let returnType = blockchain.scriptReturnType(code: "pub fun main(): Int { return 1 }")
+                Test.assert(returnType == Type<Int>())
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		scriptReturnType: func(_ *interpreter.Interpreter, code string) (sema.Type, error) {
+			assert.Equal(t, "pub fun main(): Int { return 1 }", code)
+			return sema.IntType, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainScriptReturnTypeVoid(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let returnType = blockchain.scriptReturnType(code: "pub fun main() {}")
+                Test.assert(returnType == nil)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		scriptReturnType: func(_ *interpreter.Interpreter, _ string) (sema.Type, error) {
+			return sema.VoidType, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainNextAddress(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                Test.assert(blockchain.nextAddress() == 0x01)
+                let account = blockchain.createAccount()
+                Test.assert(account.address == 0x01)
+                Test.assert(blockchain.nextAddress() == 0x02)
+            }
+        `
+
+	var nextAddress common.Address
+	nextAddress[common.AddressLength-1] = 1
+
+	testFramework := &mockedTestFramework{
+		nextAddress: func() common.Address {
+			return nextAddress
+		},
+		createAccount: func() (*Account, error) {
+			account := &Account{
+				Address: nextAddress,
+				PublicKey: &PublicKey{
+					PublicKey: []byte{1, 2, 3},
+					SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+				},
+			}
+			nextAddress[common.AddressLength-1]++
+			return account, nil
+		},
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainSequenceNumber(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let sequenceNumber = blockchain.sequenceNumber(account: 0x01, keyIndex: 2)
+                Test.assert(sequenceNumber == 5)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		sequenceNumber: func(address common.Address, keyIndex int) (uint64, error) {
+			assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+			assert.Equal(t, 2, keyIndex)
+			return 5, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainTransactionsInBlock(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let results = blockchain.transactionsInBlock(height: 1)
+                Test.assert(results.length == 2)
+                Test.assert(results[0].status == Test.ResultStatus.succeeded)
+                Test.assert(results[1].status == Test.ResultStatus.failed)
+                Test.assert(results[1].error!.message == "failed")
+
+                Test.assert(blockchain.transactionsInBlock(height: 2).length == 0)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		transactionsInBlock: func(height uint64) ([]*TransactionResult, error) {
+			if height != 1 {
+				return nil, nil
+			}
+
+			return []*TransactionResult{
+				{},
+				{Error: errors.New("failed")},
+			}, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainGetCapability(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let capability = blockchain.getCapability(address: 0x01, path: /public/foo)!
+                Test.assert(capability.address == 0x01)
+                Test.assert(capability.getType() == Type<Capability<&Int>>())
+
+                Test.assert(blockchain.getCapability(address: 0x01, path: /public/bar) == nil)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		getCapability: func(
+			address common.Address,
+			path interpreter.PathValue,
+		) (*interpreter.StorageCapabilityValue, error) {
+			if path.Identifier != "foo" {
+				return nil, nil
+			}
+
+			return interpreter.NewUnmeteredStorageCapabilityValue(
+				interpreter.AddressValue(address),
+				path,
+				interpreter.ReferenceStaticType{
+					BorrowedType: interpreter.PrimitiveStaticTypeInt,
+				},
+			), nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainExecuteAndCommit(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let pending = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.addTransaction(pending)
+                let pendingResult = blockchain.executeNextTransaction()!
+                Test.assert(pendingResult.status == Test.ResultStatus.succeeded)
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute { panic(\"failed\") } }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                let result = blockchain.executeAndCommit(tx)
+                Test.assert(result.status == Test.ResultStatus.failed)
+            }
+        `
+
+	testFramework, executed, committed := newExecuteAndCommitTestFramework(t)
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]string{
+			"transaction { execute {} }",
+			`transaction { execute { panic("failed") } }`,
+		},
+		*executed,
+	)
+	assert.True(t, *committed)
+}
+
+func TestBlockchainExecuteAndCommitWithPendingTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let pending = Test.Transaction(
+                    code: "transaction { execute { panic(\"pending\") } }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.addTransaction(pending)
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.executeAndCommit(tx)
+            }
+        `
+
+	testFramework, executed, committed := newExecuteAndCommitTestFramework(t)
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(
+		t,
+		err,
+		"cannot execute and commit a transaction: "+
+			"1 pending transaction(s) in the current block were not executed",
+	)
+
+	// The result of the failing pending transaction is not silently discarded
+	assert.Empty(t, *executed)
+	assert.False(t, *committed)
+}
+
+func TestBlockchainAllContracts(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let contracts = blockchain.allContracts(includeSystemContracts: false)
+                Test.assert(contracts.length == 2)
+                Test.assert(contracts[0x01]! == ["Foo", "Bar"])
+                Test.assert(contracts[0x02]! == ["Baz"])
+            }
+        `
+
+	var includeSystemContractsArg bool
+
+	testFramework := &mockedTestFramework{
+		allContracts: func(includeSystemContracts bool) (map[common.Address][]string, error) {
+			includeSystemContractsArg = includeSystemContracts
+			return map[common.Address][]string{
+				common.MustBytesToAddress([]byte{0x2}): {"Baz"},
+				common.MustBytesToAddress([]byte{0x1}): {"Foo", "Bar"},
+			}, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.False(t, includeSystemContractsArg)
+}
+
+func TestBlockchainTransactionPaths(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.assert(result.writtenPaths == [/storage/foo])
+                Test.assert(result.readPaths == [/storage/foo, /storage/bar])
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				WrittenPaths: []interpreter.PathValue{
+					{Domain: common.PathDomainStorage, Identifier: "foo"},
+				},
+				ReadPaths: []interpreter.PathValue{
+					{Domain: common.PathDomainStorage, Identifier: "foo"},
+					{Domain: common.PathDomainStorage, Identifier: "bar"},
+				},
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainExecuteScriptAtHeight(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let result = blockchain.executeScriptAtHeight(
+                    "pub fun main(): Int { return 42 }",
+                    [],
+                    height: 1
+                )
+                Test.assert(result.status == Test.ResultStatus.succeeded)
+                Test.assert(result.returnValue! as! Int == 42)
+
+                let futureResult = blockchain.executeScriptAtHeight(
+                    "pub fun main(): Int { return 42 }",
+                    [],
+                    height: 2
+                )
+                Test.assert(futureResult.status == Test.ResultStatus.failed)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		runScriptAtHeight: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []interpreter.Value,
+			height uint64,
+		) *ScriptResult {
+			if height > 1 {
+				return &ScriptResult{
+					Error: errors.New("block not found"),
+				}
+			}
+
+			return &ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainCheckContract(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let errors = blockchain.checkContract(
+                    name: "Foo",
+                    code: "pub contract Foo { pub fun foo() { let r <- create R() } pub resource R {} }"
+                )
+                Test.assert(errors.length == 1)
+                Test.assert(errors[0].message == "loss of resource")
+
+                Test.assert(
+                    blockchain.checkContract(name: "Bar", code: "pub contract Bar {}").length == 0
+                )
+            }
+        `
+
+	var checkedNames []string
+
+	testFramework := &mockedTestFramework{
+		checkContract: func(name string, _ string) []error {
+			checkedNames = append(checkedNames, name)
+			if name == "Foo" {
+				return []error{errors.New("loss of resource")}
+			}
+			return nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Foo", "Bar"}, checkedNames)
+}
+
+func TestBlockchainEvents(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub event Deposited(amount: UFix64)
+
+            pub event Withdrawn(amount: UFix64)
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let result = blockchain.executeNextTransaction()!
+                Test.assert(result.events.length == 2)
+                Test.assert((result.events[0] as! Withdrawn).amount == 1.0)
+                Test.assert((result.events[1] as! Deposited).amount == 2.0)
+
+                Test.assert(blockchain.events().length == 2)
+
+                let deposits = blockchain.eventsOfType(Type<Deposited>())
+                Test.assert(deposits.length == 1)
+                Test.assert((deposits[0] as! Deposited).amount == 2.0)
+            }
+        `
+
+	newEvent := func(inter *interpreter.Interpreter, identifier string, amount uint64) interpreter.Value {
+		return interpreter.NewCompositeValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			utils.TestLocation,
+			identifier,
+			common.CompositeKindEvent,
+			[]interpreter.CompositeField{
+				{
+					Name:  "amount",
+					Value: interpreter.NewUnmeteredUFix64ValueWithInteger(amount, interpreter.EmptyLocationRange),
+				},
+			},
+			common.ZeroAddress,
+		)
+	}
+
+	newEvents := func(inter *interpreter.Interpreter) []interpreter.Value {
+		return []interpreter.Value{
+			newEvent(inter, "Withdrawn", 1),
+			newEvent(inter, "Deposited", 2),
+		}
+	}
+
+	var inter *interpreter.Interpreter
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				Events: newEvents(inter),
+			}
+		},
+		events: func(inter *interpreter.Interpreter) []interpreter.Value {
+			return newEvents(inter)
+		},
+	}
+
+	var err error
+	inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestBlockchainRecentEvents(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub event Deposited(amount: UFix64)
+
+            pub fun newTransaction(_ account: Test.Account): Test.Transaction {
+                return Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+            }
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                blockchain.addTransaction(newTransaction(account))
+                blockchain.addTransaction(newTransaction(account))
+                Test.assert(blockchain.recentEvents().length == 0)
+
+                blockchain.executeNextTransaction()
+                let events = blockchain.recentEvents()
+                Test.assert(events.length == 1)
+                Test.assert((events[0] as! Deposited).amount == 1.0)
+
+                blockchain.executeNextTransaction()
+                let moreEvents = blockchain.recentEvents()
+                Test.assert(moreEvents.length == 2)
+                Test.assert((moreEvents[1] as! Deposited).amount == 2.0)
+
+                // Committing the block does not reset the recent events
+
+                blockchain.commitBlock()
+                Test.assert(blockchain.recentEvents().length == 2)
+                Test.assert(blockchain.events().length == 2)
+
+                blockchain.addTransaction(newTransaction(account))
+                blockchain.executeNextTransaction()
+                let nextBlockEvents = blockchain.recentEvents()
+                Test.assert(nextBlockEvents.length == 3)
+                Test.assert((nextBlockEvents[2] as! Deposited).amount == 3.0)
+
+                blockchain.clearEvents()
+                Test.assert(blockchain.recentEvents().length == 0)
+                Test.assert(blockchain.events().length == 0)
+            }
+        `
+
+	var inter *interpreter.Interpreter
+	var pending int
+	var executed uint64
+	var emittedAmounts []uint64
+	var recentAmounts []uint64
+
+	newEvent := func(inter *interpreter.Interpreter, amount uint64) interpreter.Value {
+		return interpreter.NewCompositeValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			utils.TestLocation,
+			"Deposited",
+			common.CompositeKindEvent,
+			[]interpreter.CompositeField{
+				{
+					Name:  "amount",
+					Value: interpreter.NewUnmeteredUFix64ValueWithInteger(amount, interpreter.EmptyLocationRange),
+				},
+			},
+			common.ZeroAddress,
+		)
+	}
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			pending++
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			if pending == 0 {
+				return nil
+			}
+			pending--
+			executed++
+			emittedAmounts = append(emittedAmounts, executed)
+			recentAmounts = append(recentAmounts, executed)
+
+			return &TransactionResult{
+				Events: []interpreter.Value{
+					newEvent(inter, executed),
+				},
+			}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+		events: func(inter *interpreter.Interpreter) []interpreter.Value {
+			events := make([]interpreter.Value, 0, len(emittedAmounts))
+			for _, amount := range emittedAmounts {
+				events = append(events, newEvent(inter, amount))
+			}
+			return events
+		},
+		recentEvents: func(inter *interpreter.Interpreter) []interpreter.Value {
+			events := make([]interpreter.Value, 0, len(recentAmounts))
+			for _, amount := range recentAmounts {
+				events = append(events, newEvent(inter, amount))
+			}
+			return events
+		},
+		clearEvents: func() {
+			emittedAmounts = nil
+			recentAmounts = nil
+		},
+	}
+
+	var err error
+	inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestBlockchainClearEvents(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub event Deposited(amount: UFix64)
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let result = blockchain.executeNextTransaction()!
+                Test.assert(blockchain.events().length == 1)
+
+                blockchain.clearEvents()
+
+                Test.assert(blockchain.events().length == 0)
+                Test.assert(result.events.length == 1)
+            }
+        `
+
+	var events []interpreter.Value
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				Events: events,
+			}
+		},
+		events: func(_ *interpreter.Interpreter) []interpreter.Value {
+			return events
+		},
+		clearEvents: func() {
+			events = nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	events = []interpreter.Value{
+		interpreter.NewCompositeValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			utils.TestLocation,
+			"Deposited",
+			common.CompositeKindEvent,
+			[]interpreter.CompositeField{
+				{
+					Name:  "amount",
+					Value: interpreter.NewUnmeteredUFix64ValueWithInteger(1, interpreter.EmptyLocationRange),
+				},
+			},
+			common.ZeroAddress,
+		),
+	}
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestBlockchainContractCodeHash(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "pub contract Foo {}"
+
+	codeHash := sha3.Sum256([]byte(code))
+
+	script := fmt.Sprintf(
+		`
+              import Test
+
+              pub fun test() {
+                  let blockchain = Test.newEmulatorBlockchain()
+                  let hash = blockchain.contractCodeHash(address: 0x1, name: "Foo")
+                  Test.assert(String.encodeHex(hash) == "%x")
+              }
+            `,
+		codeHash,
+	)
+
+	testFramework := &mockedTestFramework{
+		contractCode: func(address common.Address, name string) ([]byte, bool, error) {
+			assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+			assert.Equal(t, "Foo", name)
+			return []byte(code), true, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainContractCodeHashOfMissingContract(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.contractCodeHash(address: 0x1, name: "Foo")
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		contractCode: func(address common.Address, name string) ([]byte, bool, error) {
+			return nil, false, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(t, err, "contract `Foo` is not deployed to account 0x0000000000000001")
+}
+
+func TestBlockchainAccountKeys(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let keys = blockchain.accountKeys(address: 0x1)
+
+                Test.assert(keys.length == 2)
+
+                Test.assert(keys[0].keyIndex == 0)
+                Test.assert(keys[0].weight == 1000.0)
+                Test.assert(keys[0].publicKey.publicKey == [1, 2, 3])
+                Test.assert(keys[0].isRevoked)
+
+                Test.assert(keys[1].keyIndex == 1)
+                Test.assert(!keys[1].isRevoked)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		accountKeys: func(address common.Address) ([]*AccountKey, error) {
+			publicKey := &PublicKey{
+				PublicKey: []byte{1, 2, 3},
+				SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+			}
+
+			return []*AccountKey{
+				{
+					PublicKey: publicKey,
+					KeyIndex:  0,
+					Weight:    1000,
+					HashAlgo:  sema.HashAlgorithmSHA3_256,
+					IsRevoked: true,
+				},
+				{
+					PublicKey: publicKey,
+					KeyIndex:  1,
+					Weight:    1000,
+					HashAlgo:  sema.HashAlgorithmSHA3_256,
+				},
+			}, nil
+		},
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainTryScript(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let success = blockchain.tryScript("pub fun main(): Int { return 42 }", [])
+                Test.assert(success.success)
+                Test.assert(success.value! as! Int == 42)
+                Test.assert(success.error == nil)
+
+                let failure = blockchain.tryScript("pub fun main(): Int { panic(\"failed\") }", [])
+                Test.assert(!failure.success)
+                Test.assert(failure.value == nil)
+                Test.assert(failure.error!.message == "failed")
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		runScript: func(_ *interpreter.Interpreter, code string, _ []interpreter.Value) *ScriptResult {
+			if code == "pub fun main(): Int { return 42 }" {
+				return &ScriptResult{
+					Value: interpreter.NewUnmeteredIntValueFromInt64(42),
+				}
+			}
+
+			return &ScriptResult{
+				Error: errors.New("failed"),
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainFlowTotalSupply(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                Test.assert(blockchain.flowTotalSupply() == 1000000000.5)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		flowTotalSupply: func() (uint64, error) {
+			return 1000000000_50000000, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainExecuteTransactionsOrder(t *testing.T) {
+
+	t.Parallel()
+
+	// The transactions of the first account have the sequence numbers 0 and 1,
+	// the transaction of the second account has the sequence number 0.
+	// The transactions must be executed in array order,
+	// not in the order of their sequence numbers.
+
+	const script = `
+            import Test
+
+            pub fun newTransaction(_ code: String, _ signer: Test.Account): Test.Transaction {
+                return Test.Transaction(
+                    code: code,
+                    authorizers: [],
+                    signers: [signer],
+                    arguments: [],
+                )
+            }
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let first = blockchain.createAccount()
+                let second = blockchain.createAccount()
+
+                let results = blockchain.executeTransactions([
+                    newTransaction("first 0", first),
+                    newTransaction("first 1", first),
+                    newTransaction("second 0", second)
+                ])
+                Test.assert(results.length == 3)
+                Test.assert(results[0].status == Test.ResultStatus.succeeded)
+                Test.assert(results[1].status == Test.ResultStatus.failed)
+                Test.assert(results[2].status == Test.ResultStatus.succeeded)
+            }
+        `
+
+	type pendingTransaction struct {
+		code           string
+		sequenceNumber int
+	}
+
+	var nextAddress common.Address
+	sequenceNumbers := map[common.Address]int{}
+	var pending []pendingTransaction
+	var executed []string
+
+	testFramework := &mockedTestFramework{
+		createAccount: func() (*Account, error) {
+			nextAddress[common.AddressLength-1]++
+			return &Account{
+				PublicKey: &PublicKey{
+					PublicKey: []byte{1, 2, 3},
+					SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+				},
+				Address: nextAddress,
+			}, nil
+		},
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			code string,
+			_ []common.Address,
+			signers []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			require.Len(t, signers, 1)
+			address := signers[0].Address
+
+			pending = append(pending, pendingTransaction{
+				code:           code,
+				sequenceNumber: sequenceNumbers[address],
+			})
+			sequenceNumbers[address]++
+			return nil
+		},
+		// Like a block producer, execute the pending transactions
+		// in the order of their sequence numbers
+		executeTransaction: func() *TransactionResult {
+			if len(pending) == 0 {
+				return nil
+			}
+
+			next := 0
+			for i, tx := range pending {
+				if tx.sequenceNumber < pending[next].sequenceNumber {
+					next = i
+				}
+			}
+
+			code := pending[next].code
+			pending = append(pending[:next], pending[next+1:]...)
+			executed = append(executed, code)
+
+			if code == "first 1" {
+				return &TransactionResult{Error: errors.New("failed")}
+			}
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+		pendingTransactionCount: func() int {
+			return len(pending)
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{"first 0", "first 1", "second 0"},
+		executed,
+	)
+}
+
+func TestBlockchainExecuteTransactionsNotExecuted(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let transactions: [Test.Transaction] = []
+                for code in ["tx1", "tx2"] {
+                    transactions.append(
+                        Test.Transaction(
+                            code: code,
+                            authorizers: [],
+                            signers: [account],
+                            arguments: [],
+                        )
+                    )
+                }
+
+                blockchain.executeTransactions(transactions)
+            }
+        `
+
+	executed := 0
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			return nil
+		},
+		// Only execute the first transaction
+		executeTransaction: func() *TransactionResult {
+			executed++
+			if executed > 1 {
+				return nil
+			}
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+		// No transactions are added before the transactions are executed
+		pendingTransactionCount: func() int {
+			return 0
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(t, err, "transaction at index 1 was not executed")
+}
+
+func TestBlockchainExecuteTransactionsWithPendingTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let pending = Test.Transaction(
+                    code: "transaction { execute { panic(\"pending\") } }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.addTransaction(pending)
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.executeTransactions([tx])
+            }
+        `
+
+	testFramework, executed, committed := newExecuteAndCommitTestFramework(t)
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(
+		t,
+		err,
+		"cannot execute and commit a transaction: "+
+			"1 pending transaction(s) in the current block were not executed",
+	)
+
+	// The result of the pending transaction is not returned for the given transaction
+	assert.Empty(t, *executed)
+	assert.False(t, *committed)
+}
+
+func TestBlockchainGetBlock(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let block = blockchain.getBlock(height: 1)!
+                Test.assert(block.height == 1)
+                Test.assert(block.view == 2)
+                Test.assert(block.id[0] == 3)
+                Test.assert(block.timestamp == 4.0)
+                Test.assert(block.transactionCount == 5)
+
+                Test.assert(blockchain.getBlock(height: 2) == nil)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		getBlock: func(height uint64) (*CommittedBlock, error) {
+			if height != 1 {
+				return nil, nil
+			}
+
+			return &CommittedBlock{
+				Block: Block{
+					Height:    1,
+					View:      2,
+					Hash:      BlockHash{3},
+					Timestamp: (4 * time.Second).Nanoseconds(),
+				},
+				TransactionCount: 5,
+			}, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainExpectCheckerErrors(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.expectCheckerErrors(name: "Foo", code: "", count: 2)
+                blockchain.expectCheckerErrors(name: "Bar", code: "", count: 0)
+            }
+
+            pub fun testFail() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.expectCheckerErrors(name: "Foo", code: "", count: 1)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		checkContract: func(name string, _ string) []error {
+			if name == "Foo" {
+				return []error{
+					errors.New("loss of resource"),
+					errors.New("cannot find type"),
+				}
+			}
+			return nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testFail")
+	require.Error(t, err)
+
+	var conditionErr interpreter.ConditionError
+	require.ErrorAs(t, err, &conditionErr)
+	assert.Equal(t, "expected 1 checker error(s), got 2", conditionErr.Message)
+}
+
+func TestBlockchainContractImports(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let imports = blockchain.contractImports(
+                    name: "C",
+                    code: "import Foo from 0x01\nimport Bar from \"./Bar.cdc\"\nimport Baz from 0x01\npub contract C {}"
+                )
+                Test.assert(imports == [
+                    "0x0000000000000001.Foo",
+                    "./Bar.cdc",
+                    "0x0000000000000001.Baz"
+                ])
+
+                // Several contracts imported from the same address in one declaration,
+                // and a repeated import
+
+                let sameAddressImports = blockchain.contractImports(
+                    name: "C",
+                    code: "import Foo, Bar from 0x02\nimport Foo from 0x02\npub contract C {}"
+                )
+                Test.assert(sameAddressImports == [
+                    "0x0000000000000002.Foo",
+                    "0x0000000000000002.Bar"
+                ])
+
+                Test.assert(blockchain.contractImports(name: "C", code: "pub contract C {}").length == 0)
+            }
+
+            pub fun testInvalidCode() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.contractImports(name: "C", code: "import Foo from\npub contract C {")
+            }
+
+            pub fun testOtherContract() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.contractImports(name: "D", code: "pub contract C {}")
+            }
+        `
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, &mockedTestFramework{})
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testInvalidCode")
+	require.ErrorAs(t, err, &ContractParsingError{})
+	require.ErrorContains(t, err, "cannot parse contract `C`")
+
+	_, err = inter.Invoke("testOtherContract")
+	require.ErrorAs(t, err, &ContractNotDeclaredError{})
+	require.ErrorContains(t, err, "no such contract: code does not declare contract `D`")
+}
+
+func TestBlockchainExpectDestroyed(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.assert(result.destroyedResources == 2)
+                Test.expectDestroyed(result, count: 2)
+            }
+
+            pub fun testFail() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.expectDestroyed(result, count: 1)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				DestroyedResources: 2,
+			}
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testFail")
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &interpreter.ConditionError{})
+	assert.ErrorContains(t, err, "expected 1 destroyed resource(s), got 2")
+}
+
+func TestBlockchainTransactionExecutionStatus(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let sealed = blockchain.executeNextTransaction()!
+                Test.assert(sealed.status == Test.ResultStatus.succeeded)
+                Test.assert(sealed.executionStatus == Test.ExecutionStatus.sealed)
+
+                let expired = blockchain.executeNextTransaction()!
+                Test.assert(expired.status == Test.ResultStatus.failed)
+                Test.assert(expired.executionStatus == Test.ExecutionStatus.expired)
+            }
+        `
+
+	var executed int
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			executed++
+			if executed == 1 {
+				return &TransactionResult{
+					ExecutionStatus: ExecutionStatusSealed,
+				}
+			}
+			return &TransactionResult{
+				Error:           errors.New("transaction is expired"),
+				ExecutionStatus: ExecutionStatusExpired,
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainTransactionComputationBreakdown(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let result = blockchain.executeNextTransaction()!
+
+                Test.assert(result.computationBreakdown["Statement"] == 12)
+                Test.assert(result.computationBreakdown["Loop"] == 3)
+                Test.assert(result.computationBreakdown["DestroyDictionaryValue"] == nil)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			return &TransactionResult{
+				ComputationBreakdown: map[common.ComputationKind]uint64{
+					common.ComputationKindStatement: 12,
+					common.ComputationKindLoop:      3,
+				},
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainSetTransactionsPerBlock(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.setTransactionsPerBlock(3)
+            }
+
+            pub fun testZero() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.setTransactionsPerBlock(0)
+            }
+        `
+
+	var counts []int
+
+	testFramework := &mockedTestFramework{
+		setTransactionsPerBlock: func(count int) error {
+			counts = append(counts, count)
+			return nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testZero")
+	require.ErrorContains(t, err, "transactions per block must be positive, got 0")
+
+	assert.Equal(t, []int{3}, counts)
+}
+
+func TestBlockchainContractValue(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let version = blockchain.contractValue(address: 0x01, name: "Foo", member: "version")
+                Test.assert(version! as! String == "1.0.0")
+            }
+
+            pub fun testMissingMember() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let value = blockchain.contractValue(address: 0x01, name: "Foo", member: "bar")
+                Test.assert(value == nil)
+            }
+
+            pub fun testMissingContract() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.contractValue(address: 0x02, name: "Foo", member: "version")
+            }
+
+            pub fun testInvalidMember() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.contractValue(address: 0x01, name: "Foo", member: "version }")
+            }
+        `
+
+	var scripts []string
+
+	testFramework := &mockedTestFramework{
+		contractCode: func(address common.Address, name string) ([]byte, bool, error) {
+			if address != common.MustBytesToAddress([]byte{0x1}) || name != "Foo" {
+				return nil, false, nil
+			}
+			return []byte("pub contract Foo { pub let version: String }"), true, nil
+		},
+		runScript: func(_ *interpreter.Interpreter, code string, _ []interpreter.Value) *ScriptResult {
+			scripts = append(scripts, code)
+			if strings.Contains(code, "Foo.version") {
+				return &ScriptResult{
+					Value: interpreter.NewUnmeteredSomeValueNonCopying(
+						interpreter.NewUnmeteredStringValue("1.0.0"),
+					),
+				}
+			}
+			return &ScriptResult{
+				Error: fmt.Errorf(
+					"script failed: %w",
+					sema.CheckerError{
+						Errors: []error{
+							&sema.NotDeclaredMemberError{
+								Type: sema.IntType,
+								Name: "bar",
+							},
+						},
+					},
+				),
+			}
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testMissingMember")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testMissingContract")
+	require.ErrorContains(
+		t,
+		err,
+		"no such contract: contract `Foo` is not deployed to account 0x0000000000000002",
+	)
+
+	_, err = inter.Invoke("testInvalidMember")
+	require.ErrorContains(t, err, "invalid identifier: `version }`")
+
+	assert.Equal(t,
+		[]string{
+			"import Foo from 0x0000000000000001\n" +
+				"pub fun main(): AnyStruct? { return Foo.version }",
+			"import Foo from 0x0000000000000001\n" +
+				"pub fun main(): AnyStruct? { return Foo.bar }",
+		},
+		scripts,
+	)
+
+	// The backend reports distinct errors for missing contracts and missing members
+
+	_, err = readContractValue(
+		testFramework,
+		inter,
+		common.MustBytesToAddress([]byte{0x2}),
+		"Foo",
+		"version",
+	)
+	require.ErrorAs(t, err, &ContractNotDeployedError{})
+
+	_, err = readContractValue(
+		testFramework,
+		inter,
+		common.MustBytesToAddress([]byte{0x1}),
+		"Foo",
+		"bar",
+	)
+	require.ErrorAs(t, err, &ContractMemberNotFoundError{})
+	assert.EqualError(
+		t,
+		err,
+		"no such member: contract `Foo` deployed to account 0x0000000000000001 has no member `bar`",
+	)
+
+	// Names which are not identifiers are rejected,
+	// without running a script
+
+	scriptCount := len(scripts)
+
+	for _, names := range [][2]string{
+		{"Foo", "x }\npub fun main2() {"},
+		{"Foo", "version "},
+		{"Foo", "version.length"},
+		{"Foo Bar", "version"},
+		{"Foo.Bar", "version"},
+		{"Foo", ""},
+		{"Foo", "nil"},
+	} {
+		_, err = readContractValue(
+			testFramework,
+			inter,
+			common.MustBytesToAddress([]byte{0x1}),
+			names[0],
+			names[1],
+		)
+		require.ErrorAs(t, err, &InvalidIdentifierError{})
+	}
+
+	assert.Len(t, scripts, scriptCount)
+}
+
+func TestBlockchainExecuteTransactionTimes(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let tx = Test.Transaction(
+                    code: "mint",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+
+                let results = blockchain.executeTransactionTimes(tx, 3)
+                Test.assert(results.length == 3)
+                Test.assert(results[0].status == Test.ResultStatus.succeeded)
+                Test.assert(results[1].status == Test.ResultStatus.succeeded)
+                Test.assert(results[2].status == Test.ResultStatus.failed)
+            }
+
+            pub fun testPending() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let tx = Test.Transaction(
+                    code: "mint",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+
+                blockchain.addTransaction(tx)
+                blockchain.executeTransactionTimes(tx, 3)
+            }
+        `
+
+	var pending int
+	var executed int
+	var committed int
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			pending++
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			if pending == 0 {
+				return nil
+			}
+			pending--
+			executed++
+
+			// The mint cap is reached after two executions
+			if executed > 2 {
+				return &TransactionResult{Error: errors.New("mint cap reached")}
+			}
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			if pending > 0 {
+				return errors.New("block has un-executed transactions")
+			}
+			committed++
+			return nil
+		},
+		pendingTransactionCount: func() int {
+			return pending
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	// No empty block is committed before the first execution
+
+	assert.Equal(t, 3, executed)
+	assert.Equal(t, 3, committed)
+
+	_, err = inter.Invoke("testPending")
+	require.ErrorContains(t, err, "1 pending transaction(s) in the current block were not executed")
+
+	assert.Equal(t, 3, executed)
+	assert.Equal(t, 3, committed)
+}
+
+func TestBlockchainAccountExists(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                Test.assert(blockchain.accountExists(address: 0x01))
+                Test.assert(!blockchain.accountExists(address: 0x02))
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		accountExists: func(address common.Address) (bool, error) {
+			return address == common.MustBytesToAddress([]byte{0x1}), nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainScriptEvents(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub event Viewed(count: Int)
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let result = blockchain.executeScript("pub fun main() {}", [])
+                Test.assert(result.events.length == 1)
+                Test.assert((result.events[0] as! Viewed).count == 1)
+            }
+        `
+
+	var inter *interpreter.Interpreter
+
+	testFramework := &mockedTestFramework{
+		runScript: func(_ *interpreter.Interpreter, _ string, _ []interpreter.Value) *ScriptResult {
+			return &ScriptResult{
+				Value: interpreter.Void,
+				Events: []interpreter.Value{
+					interpreter.NewCompositeValue(
+						inter,
+						interpreter.EmptyLocationRange,
+						utils.TestLocation,
+						"Viewed",
+						common.CompositeKindEvent,
+						[]interpreter.CompositeField{
+							{
+								Name:  "count",
+								Value: interpreter.NewUnmeteredIntValueFromInt64(1),
+							},
+						},
+						common.ZeroAddress,
+					),
+				},
+			}
+		},
+	}
+
+	var err error
+	inter, err = newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+}
+
+func TestBlockchainDeployContractWithResult(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let result = blockchain.deployContractWithResult(
+                    name: "Foo",
+                    code: "pub contract Foo {}",
+                    account: account,
+                    arguments: []
+                )
+                Test.assert(result.error == nil)
+                Test.assert(result.computationUsed == 42)
+
+                let error = blockchain.deployContract(
+                    name: "Bar",
+                    code: "pub contract Bar {}",
+                    account: account,
+                    arguments: []
+                )
+                Test.assert(error!.message == "cannot deploy")
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		deployContract: func(
+			_ *interpreter.Interpreter,
+			name string,
+			_ string,
+			_ *Account,
+			_ []interpreter.Value,
+		) *DeploymentResult {
+			if name == "Bar" {
+				return &DeploymentResult{
+					Error:           errors.New("cannot deploy"),
+					ComputationUsed: 1,
+				}
+			}
+			return &DeploymentResult{
+				ComputationUsed: 42,
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainTransactionIndex(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let first = blockchain.executeNextTransaction()!
+                Test.assert(first.transactionIndex == 0)
+
+                let second = blockchain.executeNextTransaction()!
+                Test.assert(second.transactionIndex == 1)
+            }
+        `
+
+	var index int
+
+	testFramework := &mockedTestFramework{
+		executeTransaction: func() *TransactionResult {
+			result := &TransactionResult{
+				TransactionIndex: index,
+			}
+			index++
+			return result
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainCallContractFunction(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let contract = blockchain.addAccountContract(
+                    name: "Foo",
+                    code: "pub contract Foo { pub fun add(_ a: Int, _ b: Int): Int { return a + b } }",
+                    account: account,
+                    arguments: []
+                )
+                Test.assert(contract.address == account.address)
+                Test.assert(contract.name == "Foo")
+
+                let result = blockchain.callContractFunction(
+                    contract,
+                    function: "add",
+                    arguments: [1, 2]
+                )
+                Test.assert((result! as! Int) == 3)
+            }
+        `
+
+	var called bool
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		deployContract: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ string,
+			_ *Account,
+			_ []interpreter.Value,
+		) *DeploymentResult {
+			return &DeploymentResult{}
+		},
+		callContractFunction: func(
+			_ *interpreter.Interpreter,
+			address common.Address,
+			name string,
+			function string,
+			arguments []interpreter.Value,
+		) *ScriptResult {
+			called = true
+
+			assert.Equal(t, common.Address{0x1}, address)
+			assert.Equal(t, "Foo", name)
+			assert.Equal(t, "add", function)
+			require.Len(t, arguments, 2)
+
+			a := arguments[0].(interpreter.IntValue)
+			b := arguments[1].(interpreter.IntValue)
+
+			return &ScriptResult{
+				Value: interpreter.NewUnmeteredIntValueFromInt64(
+					int64(a.ToInt(interpreter.EmptyLocationRange) + b.ToInt(interpreter.EmptyLocationRange)),
+				),
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.True(t, called)
+}
+
+func TestBlockchainCallContractFunctionFailure(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let contract = Test.ContractHandle(address: 0x1, name: "Foo")
+                blockchain.callContractFunction(contract, function: "missing", arguments: [])
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		callContractFunction: func(
+			_ *interpreter.Interpreter,
+			_ common.Address,
+			_ string,
+			_ string,
+			_ []interpreter.Value,
+		) *ScriptResult {
+			return &ScriptResult{
+				Error: errors.New("function 'missing' does not exist"),
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(t, err, "function 'missing' does not exist")
+}
+
+func TestBlockchainTransactionExpiry(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                Test.assert(tx.referenceBlockHeight == nil)
+                Test.assert(tx.expiry == nil)
+                blockchain.addTransaction(tx)
+
+                let expiringTx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                expiringTx.setExpiry(referenceBlockHeight: 1, expiry: 10)
+                Test.assert(expiringTx.referenceBlockHeight == 1)
+                Test.assert(expiringTx.expiry == 10)
+                blockchain.addTransaction(expiringTx)
+
+                let result = blockchain.executeNextTransaction()!
+                Test.assert(result.executionStatus == Test.ExecutionStatus.executed)
+
+                let expiredResult = blockchain.executeNextTransaction()!
+                Test.assert(expiredResult.executionStatus == Test.ExecutionStatus.expired)
+            }
+        `
+
+	var expiries []*TransactionExpiry
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			expiry *TransactionExpiry,
+		) error {
+			expiries = append(expiries, expiry)
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			expiry := expiries[0]
+			expiries = expiries[1:]
+
+			if expiry != nil {
+				assert.Equal(t,
+					&TransactionExpiry{
+						ReferenceBlockHeight: 1,
+						Expiry:               10,
+					},
+					expiry,
+				)
+
+				return &TransactionResult{
+					Error:           errors.New("transaction is expired"),
+					ExecutionStatus: ExecutionStatusExpired,
+				}
+			}
+			return &TransactionResult{
+				ExecutionStatus: ExecutionStatusExecuted,
+			}
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainRecordAndReplayTransactions(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun newTransaction(_ code: String, _ account: Test.Account): Test.Transaction {
+                return Test.Transaction(
+                    code: code,
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+            }
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                // Not recorded
+                blockchain.executeTransaction(newTransaction("transaction { execute { log(0) } }", account))
+
+                blockchain.recordTransactions()
+                blockchain.executeTransaction(newTransaction("transaction { execute { log(1) } }", account))
+                blockchain.executeTransactions([
+                    newTransaction("transaction { execute { log(2) } }", account),
+                    newTransaction("transaction { execute { log(3) } }", account)
+                ])
+                blockchain.stopRecording()
+
+                // Not recorded
+                blockchain.executeTransaction(newTransaction("transaction { execute { log(4) } }", account))
+
+                let recording = blockchain.transactionRecording()
+                Test.assert(recording.length == 3)
+                Test.assert(recording[0].code == "transaction { execute { log(1) } }")
+
+                let replayBlockchain = Test.newEmulatorBlockchain()
+                let replayAccount = replayBlockchain.createAccount()
+                Test.assert(replayAccount.address == account.address)
+
+                let results = blockchain.replayTransactions(on: replayBlockchain)
+                Test.assert(results.length == 3)
+            }
+        `
+
+	var executed []string
+	var pending []string
+	var signerKeys [][]byte
+	var createdAccounts byte
+
+	testFramework := &mockedTestFramework{
+		createAccount: func() (*Account, error) {
+			// Each blockchain creates the account with the same address,
+			// but with a different key
+			createdAccounts++
+			return &Account{
+				PublicKey: &PublicKey{
+					PublicKey: []byte{createdAccounts},
+					SignAlgo:  sema.SignatureAlgorithmECDSA_P256,
+				},
+				Address: common.Address{0x1},
+			}, nil
+		},
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			code string,
+			_ []common.Address,
+			signers []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			pending = append(pending, code)
+			signerKeys = append(signerKeys, signers[0].PublicKey.PublicKey)
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			if len(pending) == 0 {
+				return nil
+			}
+			executed = append(executed, pending[0])
+			pending = pending[1:]
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+		pendingTransactionCount: func() int {
+			return len(pending)
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{
+			"transaction { execute { log(0) } }",
+			"transaction { execute { log(1) } }",
+			"transaction { execute { log(2) } }",
+			"transaction { execute { log(3) } }",
+			"transaction { execute { log(4) } }",
+			// Replayed
+			"transaction { execute { log(1) } }",
+			"transaction { execute { log(2) } }",
+			"transaction { execute { log(3) } }",
+		},
+		executed,
+	)
+
+	// The replayed transactions are signed by the account of the replay blockchain
+	assert.Equal(t,
+		[][]byte{
+			{1}, {1}, {1}, {1}, {1},
+			// Replayed
+			{2}, {2}, {2},
+		},
+		signerKeys,
+	)
+}
+
+func TestBlockchainReplayTransactionsWithoutSignerAccount(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                blockchain.recordTransactions()
+                blockchain.executeTransaction(
+                    Test.Transaction(
+                        code: "transaction { execute {} }",
+                        authorizers: [],
+                        signers: [account],
+                        arguments: [],
+                    )
+                )
+
+                blockchain.replayTransactions(on: Test.newEmulatorBlockchain())
+            }
+        `
+
+	var pending int
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			pending++
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			if pending == 0 {
+				return nil
+			}
+			pending--
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.ErrorContains(
+		t,
+		err,
+		"cannot replay transaction at index 0: "+
+			"signer 0x0100000000000000 is not an account of the target blockchain",
+	)
+}
+
+func TestBlockchainGetContractCode(t *testing.T) {
+
+	t.Parallel()
+
+	const code = "pub contract Foo {}"
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                Test.assert(blockchain.getContractCode(address: 0x1, name: "Foo") == "pub contract Foo {}")
+                Test.assert(blockchain.getContractCode(address: 0x1, name: "Bar") == nil)
+                Test.assert(blockchain.getContractCode(address: 0x2, name: "Foo") == nil)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		contractCode: func(address common.Address, name string) ([]byte, bool, error) {
+			if address != common.MustBytesToAddress([]byte{0x1}) || name != "Foo" {
+				return nil, false, nil
+			}
+			return []byte(code), true, nil
+		},
+	}
+
+	_, err := invokeTestFunctionWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+}
+
+func TestBlockchainExpectBalanceChange(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun newTransaction(_ account: Test.Account): Test.Transaction {
+                return Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+            }
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                blockchain.expectBalanceChange(address: 0x1, by: -1.5, fun () {
+                    blockchain.executeTransaction(newTransaction(account))
+                })
+
+                blockchain.expectBalanceChangeWithFees(address: 0x1, by: -1.0, maxFees: 0.5, fun () {
+                    blockchain.executeTransaction(newTransaction(account))
+                })
+            }
+
+            pub fun testUnexpectedChange() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                blockchain.expectBalanceChange(address: 0x1, by: -1.0, fun () {
+                    blockchain.executeTransaction(newTransaction(account))
+                })
+            }
+
+            pub fun testUnexpectedFees() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                blockchain.expectBalanceChangeWithFees(address: 0x1, by: -1.0, maxFees: 0.25, fun () {
+                    blockchain.executeTransaction(newTransaction(account))
+                })
+            }
+        `
+
+	// Each transaction transfers 1.0 and pays 0.5 fees
+	balance := interpreter.NewUnmeteredUFix64Value(10_00000000)
+
+	testFramework := &mockedTestFramework{
+		createAccount: createTestAccount,
+		stdlibHandler: func() StandardLibraryHandler {
+			return nil
+		},
+		runScript: func(_ *interpreter.Interpreter, _ string, arguments []interpreter.Value) *ScriptResult {
+			require.Len(t, arguments, 1)
+			assert.Equal(t,
+				interpreter.AddressValue(common.MustBytesToAddress([]byte{0x1})),
+				arguments[0],
+			)
+			return &ScriptResult{
+				Value: balance,
+			}
+		},
+		addTransaction: func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			_ *TransactionExpiry,
+		) error {
+			return nil
+		},
+		executeTransaction: func() *TransactionResult {
+			balance -= 1_50000000
+			return &TransactionResult{}
+		},
+		commitBlock: func() error {
+			return nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testUnexpectedChange")
+	require.ErrorContains(t, err, "balance changed by -1.50000000, expected -1.00000000")
+
+	_, err = inter.Invoke("testUnexpectedFees")
+	require.ErrorContains(t, err, "balance changed by -1.50000000, expected -1.00000000 minus at most 0.25000000 fees")
+}
+
+func TestBlockchainSpyOn(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let spy = blockchain.spyOn(address: 0x1, contract: "Foo", function: "bar")
+
+                Test.expectCalled(spy, times: 2)
+
+                let calls = spy.calls()
+                Test.assert(calls[0][0] as! Int == 1)
+                Test.assert(calls[1][0] as! Int == 2)
+            }
+
+            pub fun testUnexpectedCount() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let spy = blockchain.spyOn(address: 0x1, contract: "Foo", function: "bar")
+
+                Test.expectCalled(spy, times: 1)
+            }
+
+            pub fun testMissingContract() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.spyOn(address: 0x1, contract: "Baz", function: "bar")
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		spyOn: func(address common.Address, contractName string, functionName string) error {
+			assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+			assert.Equal(t, "bar", functionName)
+
+			if contractName != "Foo" {
+				return fmt.Errorf("contract %s is not deployed", contractName)
+			}
+
+			return nil
+		},
+		functionCalls: func(address common.Address, contractName string, functionName string) ([][]interpreter.Value, error) {
+			assert.Equal(t, common.MustBytesToAddress([]byte{0x1}), address)
+			assert.Equal(t, "Foo", contractName)
+			assert.Equal(t, "bar", functionName)
+
+			return [][]interpreter.Value{
+				{interpreter.NewUnmeteredIntValueFromInt64(1)},
+				{interpreter.NewUnmeteredIntValueFromInt64(2)},
+			}, nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testUnexpectedCount")
+	require.ErrorContains(t, err, "expected `Foo.bar` to be called 1 times, but was called 2 times")
+
+	_, err = inter.Invoke("testMissingContract")
+	require.ErrorContains(t, err, "contract Baz is not deployed")
+}
+
+func TestBlockchainCheckTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let validErrors = blockchain.checkTransaction("transaction { prepare(acct: AuthAccount) {} }")
+                Test.assert(validErrors.length == 0)
+
+                let errors = blockchain.checkTransaction(
+                    "transaction { prepare(acct: Int) {} execute { let x: String = 1; foo() } }"
+                )
+                Test.assert(errors.length == 3)
+                Test.assert(errors[0].kind == Test.ErrorKind.generic)
+                Test.assert(errors[2].message == "cannot find variable in this scope: ` + "`foo`" + `")
+            }
+
+            pub fun testParsingError() {
+                let blockchain = Test.newEmulatorBlockchain()
+
+                let errors = blockchain.checkTransaction("transaction {")
+                Test.assert(errors.length == 1)
+            }
+        `
+
+	testFramework := &mockedTestFramework{
+		checkTransaction: func(code string) []error {
+			program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+			if err != nil {
+				var parserErr parser.Error
+				require.ErrorAs(t, err, &parserErr)
+				return parserErr.Errors
+			}
+
+			checker, err := sema.NewChecker(
+				program,
+				utils.TestLocation,
+				nil,
+				&sema.Config{
+					AccessCheckMode: sema.AccessCheckModeStrict,
+				},
+			)
+			require.NoError(t, err)
+
+			err = checker.Check()
+			if err != nil {
+				var checkerErr *sema.CheckerError
+				require.ErrorAs(t, err, &checkerErr)
+				return checkerErr.Errors
+			}
+
+			return nil
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testParsingError")
+	require.NoError(t, err)
+}
+
+// baseTestFramework only implements the TestFramework interface,
+// and none of its optional extensions.
+type baseTestFramework struct {
+	TestFramework
+}
+
+func TestBlockchainTestFrameworkExtensions(t *testing.T) {
+
+	t.Parallel()
+
+	newTestFramework := func() *mockedTestFramework {
+		return &mockedTestFramework{
+			createAccount: createTestAccount,
+			stdlibHandler: func() StandardLibraryHandler {
+				return nil
+			},
+		}
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                blockchain.nextAddress()
+            }
+        `
+
+		testFramework := newTestFramework()
+		testFramework.nextAddress = func() common.Address {
+			return common.Address{0x1}
+		}
+
+		_, err := invokeTestFunctionWithTestFramework(
+			t,
+			script,
+			baseTestFramework{TestFramework: testFramework},
+		)
+
+		var unsupportedErr UnsupportedTestFrameworkFeatureError
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, "nextAddress", unsupportedErr.FunctionName)
+	})
+
+	t.Run("deployContractWithResult", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let result = blockchain.deployContractWithResult(
+                    name: "Foo",
+                    code: "pub contract Foo {}",
+                    account: account,
+                    arguments: []
+                )
+                Test.assert(result.error == nil)
+                Test.assert(result.computationUsed == 0)
+
+                let error = blockchain.deployContract(
+                    name: "Bar",
+                    code: "pub contract Bar {}",
+                    account: account,
+                    arguments: []
+                )
+                Test.assert(error!.message == "cannot deploy")
+            }
+        `
+
+		testFramework := newTestFramework()
+		testFramework.deployContract = func(
+			_ *interpreter.Interpreter,
+			name string,
+			_ string,
+			_ *Account,
+			_ []interpreter.Value,
+		) *DeploymentResult {
+			if name == "Bar" {
+				return &DeploymentResult{
+					Error: errors.New("cannot deploy"),
+				}
+			}
+			return &DeploymentResult{
+				ComputationUsed: 42,
+			}
+		}
+
+		_, err := invokeTestFunctionWithTestFramework(
+			t,
+			script,
+			baseTestFramework{TestFramework: testFramework},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("transaction expiry", func(t *testing.T) {
+		t.Parallel()
+
+		const script = `
+            import Test
+
+            pub fun test() {
+                let blockchain = Test.newEmulatorBlockchain()
+                let account = blockchain.createAccount()
+
+                let tx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                blockchain.addTransaction(tx)
+
+                let expiringTx = Test.Transaction(
+                    code: "transaction { execute {} }",
+                    authorizers: [],
+                    signers: [account],
+                    arguments: [],
+                )
+                expiringTx.setExpiry(referenceBlockHeight: 1, expiry: 10)
+                blockchain.addTransaction(expiringTx)
+            }
+        `
+
+		addedTransactions := 0
+
+		testFramework := newTestFramework()
+		testFramework.addTransaction = func(
+			_ *interpreter.Interpreter,
+			_ string,
+			_ []common.Address,
+			_ []*Account,
+			_ []interpreter.Value,
+			expiry *TransactionExpiry,
+		) error {
+			assert.Nil(t, expiry)
+			addedTransactions++
+			return nil
+		}
+
+		_, err := invokeTestFunctionWithTestFramework(
+			t,
+			script,
+			baseTestFramework{TestFramework: testFramework},
+		)
+
+		var unsupportedErr UnsupportedTestFrameworkFeatureError
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, "addTransaction", unsupportedErr.FunctionName)
+
+		assert.Equal(t, 1, addedTransactions)
+	})
+}
+
+func TestBlockchainErrorUnwrapping(t *testing.T) {
+
+	t.Parallel()
+
+	// Errors reported by the test framework must remain accessible
+	// through the error chain, so test providers can inspect them.
+
+	const script = `
+        import Test
+
+        pub fun test() {
+            let blockchain = Test.newEmulatorBlockchain()
+            blockchain.commitBlock()
+        }
+    `
+
+	commitErr := &testFrameworkError{message: "cannot commit block"}
+
+	testFramework := &mockedTestFramework{
+		commitBlock: func() error {
+			return commitErr
+		},
+	}
+
+	inter, err := newTestContractInterpreterWithTestFramework(t, script, testFramework)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.Error(t, err)
+
+	var interpreterErr interpreter.Error
+	require.ErrorAs(t, err, &interpreterErr)
+
+	var frameworkErr *testFrameworkError
+	require.ErrorAs(t, err, &frameworkErr)
+	assert.Same(t, commitErr, frameworkErr)
+}
+
+type testFrameworkError struct {
+	message string
+}
+
+func (e *testFrameworkError) Error() string {
+	return e.message
+}
+
+type mockedTestFramework struct {
+	runScript               func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value) *ScriptResult
+	scriptReturnType        func(inter *interpreter.Interpreter, code string) (sema.Type, error)
+	createAccount           func() (*Account, error)
+	addTransaction          func(inter *interpreter.Interpreter, code string, authorizers []common.Address, signers []*Account, arguments []interpreter.Value, expiry *TransactionExpiry) error
+	executeTransaction      func() *TransactionResult
+	commitBlock             func() error
+	deployContract          func(inter *interpreter.Interpreter, name string, code string, account *Account, arguments []interpreter.Value) *DeploymentResult
+	nextAddress             func() common.Address
+	sequenceNumber          func(address common.Address, keyIndex int) (uint64, error)
+	transactionsInBlock     func(height uint64) ([]*TransactionResult, error)
+	getCapability           func(address common.Address, path interpreter.PathValue) (*interpreter.StorageCapabilityValue, error)
+	allContracts            func(includeSystemContracts bool) (map[common.Address][]string, error)
+	runScriptAtHeight       func(inter *interpreter.Interpreter, code string, arguments []interpreter.Value, height uint64) *ScriptResult
+	checkContract           func(name string, code string) []error
+	events                  func(inter *interpreter.Interpreter) []interpreter.Value
+	recentEvents            func(inter *interpreter.Interpreter) []interpreter.Value
+	clearEvents             func()
+	contractCode            func(address common.Address, name string) ([]byte, bool, error)
+	accountKeys             func(address common.Address) ([]*AccountKey, error)
+	flowTotalSupply         func() (uint64, error)
+	getBlock                func(height uint64) (*CommittedBlock, error)
+	setTransactionsPerBlock func(count int) error
+	accountExists           func(address common.Address) (bool, error)
+	callContractFunction    func(inter *interpreter.Interpreter, address common.Address, name string, function string, arguments []interpreter.Value) *ScriptResult
+	spyOn                   func(address common.Address, contractName string, functionName string) error
+	functionCalls           func(address common.Address, contractName string, functionName string) ([][]interpreter.Value, error)
+	checkTransaction        func(code string) []error
+	pendingTransactionCount func() int
+	readFile                func(path string) (string, error)
+	stateCommitment         func() ([]byte, error)
+	encodeJSON              func(inter *interpreter.Interpreter, value interpreter.Value) ([]byte, error)
+	updateGoldenFile        func(path string, content []byte) (bool, error)
+	useConfiguration        func(configuration *Configuration)
+	stdlibHandler           func() StandardLibraryHandler
+}
+
+var _ TestFramework = &mockedTestFramework{}
+var _ ScriptReturnTypeTestFramework = &mockedTestFramework{}
+var _ NextAddressTestFramework = &mockedTestFramework{}
+var _ SequenceNumberTestFramework = &mockedTestFramework{}
+var _ TransactionsInBlockTestFramework = &mockedTestFramework{}
+var _ GetCapabilityTestFramework = &mockedTestFramework{}
+var _ AllContractsTestFramework = &mockedTestFramework{}
+var _ RunScriptAtHeightTestFramework = &mockedTestFramework{}
+var _ CheckContractTestFramework = &mockedTestFramework{}
+var _ EventsTestFramework = &mockedTestFramework{}
+var _ ContractCodeTestFramework = &mockedTestFramework{}
+var _ AccountKeysTestFramework = &mockedTestFramework{}
+var _ FlowTotalSupplyTestFramework = &mockedTestFramework{}
+var _ GetBlockTestFramework = &mockedTestFramework{}
+var _ SetTransactionsPerBlockTestFramework = &mockedTestFramework{}
+var _ AccountExistsTestFramework = &mockedTestFramework{}
+var _ StateCommitmentTestFramework = &mockedTestFramework{}
+var _ GoldenFileTestFramework = &mockedTestFramework{}
+var _ DeploymentResultTestFramework = &mockedTestFramework{}
+var _ CallContractFunctionTestFramework = &mockedTestFramework{}
+var _ TransactionExpiryTestFramework = &mockedTestFramework{}
+var _ SpyTestFramework = &mockedTestFramework{}
+var _ CheckTransactionTestFramework = &mockedTestFramework{}
+var _ PendingTransactionsTestFramework = &mockedTestFramework{}
+
+func (m *mockedTestFramework) RunScript(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+) *ScriptResult {
+	if m.runScript == nil {
+		panic("'RunScript' is not implemented")
+	}
+
+	return m.runScript(inter, code, arguments)
+}
+
+func (m *mockedTestFramework) ScriptReturnType(
+	inter *interpreter.Interpreter,
+	code string,
+) (sema.Type, error) {
+	if m.scriptReturnType == nil {
+		panic("'ScriptReturnType' is not implemented")
+	}
+
+	return m.scriptReturnType(inter, code)
+}
+
+func (m *mockedTestFramework) CreateAccount() (*Account, error) {
+	if m.createAccount == nil {
+		panic("'CreateAccount' is not implemented")
+	}
+
+	return m.createAccount()
+}
+
+func (m *mockedTestFramework) AddTransaction(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	signers []*Account,
+	arguments []interpreter.Value,
+) error {
+	if m.addTransaction == nil {
+		panic("'AddTransaction' is not implemented")
+	}
+
+	return m.addTransaction(inter, code, authorizers, signers, arguments, nil)
+}
+
+func (m *mockedTestFramework) AddTransactionWithExpiry(
+	inter *interpreter.Interpreter,
+	code string,
+	authorizers []common.Address,
+	signers []*Account,
+	arguments []interpreter.Value,
+	expiry *TransactionExpiry,
+) error {
+	if m.addTransaction == nil {
+		panic("'AddTransactionWithExpiry' is not implemented")
+	}
+
+	return m.addTransaction(inter, code, authorizers, signers, arguments, expiry)
+}
+
+func (m *mockedTestFramework) ExecuteNextTransaction() *TransactionResult {
+	if m.executeTransaction == nil {
+		panic("'ExecuteNextTransaction' is not implemented")
+	}
+
+	return m.executeTransaction()
+}
+
+func (m *mockedTestFramework) CommitBlock() error {
+	if m.commitBlock == nil {
+		panic("'CommitBlock' is not implemented")
+	}
+
+	return m.commitBlock()
+}
+
+func (m *mockedTestFramework) DeployContract(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *Account,
+	arguments []interpreter.Value,
+) error {
+	if m.deployContract == nil {
+		panic("'DeployContract' is not implemented")
+	}
+
+	return m.deployContract(inter, name, code, account, arguments).Error
+}
+
+func (m *mockedTestFramework) DeployContractWithResult(
+	inter *interpreter.Interpreter,
+	name string,
+	code string,
+	account *Account,
+	arguments []interpreter.Value,
+) *DeploymentResult {
+	if m.deployContract == nil {
+		panic("'DeployContractWithResult' is not implemented")
+	}
+
+	return m.deployContract(inter, name, code, account, arguments)
+}
+
+func (m *mockedTestFramework) NextAddress() common.Address {
+	if m.nextAddress == nil {
+		panic("'NextAddress' is not implemented")
+	}
+
+	return m.nextAddress()
+}
+
+func (m *mockedTestFramework) SequenceNumber(address common.Address, keyIndex int) (uint64, error) {
+	if m.sequenceNumber == nil {
+		panic("'SequenceNumber' is not implemented")
+	}
+
+	return m.sequenceNumber(address, keyIndex)
+}
+
+func (m *mockedTestFramework) TransactionsInBlock(height uint64) ([]*TransactionResult, error) {
+	if m.transactionsInBlock == nil {
+		panic("'TransactionsInBlock' is not implemented")
+	}
+
+	return m.transactionsInBlock(height)
+}
+
+func (m *mockedTestFramework) GetCapability(
+	address common.Address,
+	path interpreter.PathValue,
+) (*interpreter.StorageCapabilityValue, error) {
+	if m.getCapability == nil {
+		panic("'GetCapability' is not implemented")
+	}
+
+	return m.getCapability(address, path)
+}
+
+func (m *mockedTestFramework) AllContracts(includeSystemContracts bool) (map[common.Address][]string, error) {
+	if m.allContracts == nil {
+		panic("'AllContracts' is not implemented")
+	}
+
+	return m.allContracts(includeSystemContracts)
+}
+
+func (m *mockedTestFramework) RunScriptAtHeight(
+	inter *interpreter.Interpreter,
+	code string,
+	arguments []interpreter.Value,
+	height uint64,
+) *ScriptResult {
+	if m.runScriptAtHeight == nil {
+		panic("'RunScriptAtHeight' is not implemented")
+	}
+
+	return m.runScriptAtHeight(inter, code, arguments, height)
+}
+
+func (m *mockedTestFramework) CheckContract(name string, code string) []error {
+	if m.checkContract == nil {
+		panic("'CheckContract' is not implemented")
+	}
+
+	return m.checkContract(name, code)
+}
+
+func (m *mockedTestFramework) Events(inter *interpreter.Interpreter) []interpreter.Value {
+	if m.events == nil {
+		panic("'Events' is not implemented")
+	}
+
+	return m.events(inter)
+}
+
+func (m *mockedTestFramework) RecentEvents(inter *interpreter.Interpreter) []interpreter.Value {
+	if m.recentEvents == nil {
+		panic("'RecentEvents' is not implemented")
+	}
+
+	return m.recentEvents(inter)
+}
+
+func (m *mockedTestFramework) ClearEvents() {
+	if m.clearEvents == nil {
+		panic("'ClearEvents' is not implemented")
+	}
+
+	m.clearEvents()
+}
+
+func (m *mockedTestFramework) ContractCode(address common.Address, name string) ([]byte, bool, error) {
+	if m.contractCode == nil {
+		panic("'ContractCode' is not implemented")
+	}
+
+	return m.contractCode(address, name)
+}
+
+func (m *mockedTestFramework) AccountKeys(address common.Address) ([]*AccountKey, error) {
+	if m.accountKeys == nil {
+		panic("'AccountKeys' is not implemented")
+	}
+
+	return m.accountKeys(address)
+}
+
+func (m *mockedTestFramework) FlowTotalSupply() (uint64, error) {
+	if m.flowTotalSupply == nil {
+		panic("'FlowTotalSupply' is not implemented")
+	}
+
+	return m.flowTotalSupply()
+}
+
+func (m *mockedTestFramework) GetBlock(height uint64) (*CommittedBlock, error) {
+	if m.getBlock == nil {
+		panic("'GetBlock' is not implemented")
+	}
+
+	return m.getBlock(height)
+}
+
+func (m *mockedTestFramework) SetTransactionsPerBlock(count int) error {
+	if m.setTransactionsPerBlock == nil {
+		panic("'SetTransactionsPerBlock' is not implemented")
+	}
+
+	return m.setTransactionsPerBlock(count)
+}
+
+func (m *mockedTestFramework) AccountExists(address common.Address) (bool, error) {
+	if m.accountExists == nil {
+		panic("'AccountExists' is not implemented")
+	}
+
+	return m.accountExists(address)
+}
+
+func (m *mockedTestFramework) StateCommitment() ([]byte, error) {
+	if m.stateCommitment == nil {
+		panic("'StateCommitment' is not implemented")
+	}
+
+	return m.stateCommitment()
+}
+
+func (m *mockedTestFramework) CallContractFunction(
+	inter *interpreter.Interpreter,
+	address common.Address,
+	name string,
+	function string,
+	arguments []interpreter.Value,
+) *ScriptResult {
+	if m.callContractFunction == nil {
+		panic("'CallContractFunction' is not implemented")
+	}
+
+	return m.callContractFunction(inter, address, name, function, arguments)
+}
+
+func (m *mockedTestFramework) SpyOn(address common.Address, contractName string, functionName string) error {
+	if m.spyOn == nil {
+		panic("'SpyOn' is not implemented")
+	}
+
+	return m.spyOn(address, contractName, functionName)
+}
+
+func (m *mockedTestFramework) FunctionCalls(
+	address common.Address,
+	contractName string,
+	functionName string,
+) ([][]interpreter.Value, error) {
+	if m.functionCalls == nil {
+		panic("'FunctionCalls' is not implemented")
+	}
+
+	return m.functionCalls(address, contractName, functionName)
+}
+
+func (m *mockedTestFramework) CheckTransaction(code string) []error {
+	if m.checkTransaction == nil {
+		panic("'CheckTransaction' is not implemented")
+	}
+
+	return m.checkTransaction(code)
+}
+
+func (m *mockedTestFramework) PendingTransactionCount() int {
+	if m.pendingTransactionCount == nil {
+		panic("'PendingTransactionCount' is not implemented")
+	}
+
+	return m.pendingTransactionCount()
+}
+
+func (m *mockedTestFramework) ReadFile(path string) (string, error) {
+	if m.readFile == nil {
+		panic("'ReadFile' is not implemented")
+	}
+
+	return m.readFile(path)
+}
+
+func (m *mockedTestFramework) EncodeJSON(
+	inter *interpreter.Interpreter,
+	value interpreter.Value,
+) ([]byte, error) {
+	if m.encodeJSON == nil {
+		panic("'EncodeJSON' is not implemented")
+	}
+
+	return m.encodeJSON(inter, value)
+}
+
+func (m *mockedTestFramework) UpdateGoldenFile(path string, content []byte) (bool, error) {
+	if m.updateGoldenFile == nil {
+		panic("'UpdateGoldenFile' is not implemented")
+	}
+
+	return m.updateGoldenFile(path, content)
+}
+
+func (m *mockedTestFramework) UseConfiguration(configuration *Configuration) {
+	if m.useConfiguration == nil {
+		panic("'UseConfiguration' is not implemented")
+	}
+
+	m.useConfiguration(configuration)
+}
+
+func (m *mockedTestFramework) StandardLibraryHandler() StandardLibraryHandler {
+	if m.stdlibHandler == nil {
+		panic("'StandardLibraryHandler' is not implemented")
+	}
+
+	return m.stdlibHandler()
+}
+
+func TestTestInterpreterContractValueHandler(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, location common.Location) *interpreter.CompositeValue {
+
+		const code = `
+          pub contract Foo {}
+        `
+
+		program, err := parser.ParseProgram(
+			nil,
+			[]byte(code),
+			parser.Config{},
+		)
+		require.NoError(t, err)
+
+		checker, err := sema.NewChecker(
+			program,
+			location,
+			nil,
+			&sema.Config{
+				AccessCheckMode: sema.AccessCheckModeStrict,
+			},
+		)
+		require.NoError(t, err)
+
+		err = checker.Check()
+		require.NoError(t, err)
+
+		inter, err := interpreter.NewInterpreter(
+			interpreter.ProgramFromChecker(checker),
+			checker.Location,
+			&interpreter.Config{
+				Storage:              newUnmeteredInMemoryStorage(),
+				ContractValueHandler: NewTestInterpreterContractValueHandler(nil),
+			},
+		)
+		require.NoError(t, err)
+
+		err = inter.Interpret()
+		require.NoError(t, err)
+
+		// During tests, contracts are constructed using their constructor,
+		// similar to structs
+		constructor, ok := inter.Globals.Get("Foo").GetValue().(interpreter.FunctionValue)
+		require.True(t, ok)
+
+		result, err := inter.InvokeExternally(constructor, constructor.FunctionType(), nil)
+		require.NoError(t, err)
+
+		contract, ok := result.(*interpreter.CompositeValue)
+		require.True(t, ok)
+
+		return contract
+	}
+
+	t.Run("address location", func(t *testing.T) {
+		t.Parallel()
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		contract := test(t, common.AddressLocation{
+			Address: address,
+			Name:    "Foo",
+		})
+
+		assert.Equal(t, address, contract.GetOwner())
+	})
+
+	t.Run("non-address location", func(t *testing.T) {
+		t.Parallel()
+
+		contract := test(t, utils.TestLocation)
+
+		assert.Equal(t, common.ZeroAddress, contract.GetOwner())
 	})
 }
@@ -709,3 +709,56 @@ func TestCheckFixedPointMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFixedPointRounding(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("UFix64", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x: UFix64 = 1.5
+          let floor = x.floor()
+          let ceil = x.ceil()
+          let round = x.round(places: 0)
+        `)
+		require.NoError(t, err)
+
+		for _, name := range []string{"floor", "ceil", "round"} {
+			assert.Equal(t,
+				sema.UFix64Type,
+				RequireGlobalValue(t, checker.Elaboration, name),
+			)
+		}
+	})
+
+	t.Run("Fix64", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x: Fix64 = 1.5
+          let floor = x.floor()
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("invalid places", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x: UFix64 = 1.5
+          let round = x.round(places: -1)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidIntegerLiteralRangeError{}, errs[0])
+	})
+}
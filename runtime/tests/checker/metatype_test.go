@@ -65,6 +65,32 @@ func TestCheckMetaType(t *testing.T) {
 	})
 }
 
+func TestCheckMetaTypeElementAndKeyType(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let type = Type<{String: [Int]}>()
+      let elementType = type.elementType
+      let keyType = type.keyType
+    `)
+
+	require.NoError(t, err)
+
+	optionalMetaType := &sema.OptionalType{
+		Type: sema.MetaType,
+	}
+
+	assert.Equal(t,
+		optionalMetaType,
+		RequireGlobalValue(t, checker.Elaboration, "elementType"),
+	)
+	assert.Equal(t,
+		optionalMetaType,
+		RequireGlobalValue(t, checker.Elaboration, "keyType"),
+	)
+}
+
 func TestCheckIsInstance(t *testing.T) {
 
 	t.Parallel()
@@ -237,6 +237,167 @@ func TestInterpretFunctionPostConditionWithBeforeFailingPostCondition(t *testing
 	)
 }
 
+func TestInterpretPostConditionWithBeforeOnArrayAndCompositeFields(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expectedErr bool) {
+		inter := parseCheckAndInterpret(t, code)
+
+		err := inter.InvokeTransaction(0)
+		if !expectedErr {
+			require.NoError(t, err)
+			return
+		}
+
+		RequireError(t, err)
+
+		var conditionErr interpreter.ConditionError
+		require.ErrorAs(t, err, &conditionErr)
+
+		assert.Equal(t,
+			ast.ConditionKindPost,
+			conditionErr.ConditionKind,
+		)
+	}
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          transaction {
+
+            var xs: [Int]
+
+            prepare() {
+              self.xs = [1, 2]
+            }
+
+            execute {
+              self.xs.append(3)
+              self.xs[0] = 10
+            }
+
+            post {
+              self.xs.length == before(self.xs.length) + 1
+              self.xs[0] == before(self.xs[0]) + 9
+              self.xs[1] == before(self.xs[1])
+              before(self.xs).length == 2
+            }
+          }
+        `
+
+		test(t, code, false)
+	})
+
+	t.Run("array, failing", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          transaction {
+
+            var xs: [Int]
+
+            prepare() {
+              self.xs = [1, 2]
+            }
+
+            execute {
+              self.xs.append(3)
+            }
+
+            post {
+              self.xs.length == before(self.xs.length)
+            }
+          }
+        `
+
+		test(t, code, true)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub struct Counter {
+            pub var count: Int
+
+            init() {
+              self.count = 0
+            }
+
+            pub fun increment() {
+              post {
+                self.count == before(self.count) + 1
+              }
+              self.count = self.count + 1
+            }
+          }
+
+          transaction {
+
+            let counter: Counter
+
+            prepare() {
+              self.counter = Counter()
+            }
+
+            execute {
+              self.counter.increment()
+              self.counter.increment()
+            }
+
+            post {
+              self.counter.count == before(self.counter.count) + 2
+              before(self.counter).count == 0
+            }
+          }
+        `
+
+		test(t, code, false)
+	})
+
+	t.Run("composite, failing", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub struct Counter {
+            pub var count: Int
+
+            init() {
+              self.count = 0
+            }
+
+            pub fun increment() {
+              post {
+                self.count == before(self.count) + 1
+              }
+              self.count = self.count + 2
+            }
+          }
+
+          transaction {
+
+            let counter: Counter
+
+            prepare() {
+              self.counter = Counter()
+            }
+
+            execute {
+              self.counter.increment()
+            }
+          }
+        `
+
+		test(t, code, true)
+	})
+}
+
 func TestInterpretFunctionPostConditionWithMessageUsingStringLiteral(t *testing.T) {
 
 	t.Parallel()
@@ -19,6 +19,8 @@
 package interpreter_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 func TestInterpretEnum(t *testing.T) {
@@ -185,6 +188,93 @@ func TestInterpretEnumConstructor(t *testing.T) {
 	)
 }
 
+func TestInterpretEnumRawValueRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	// Enum cases have consecutive raw values, starting at zero.
+	// Raw values outside of this range, including the minimum and maximum
+	// of the raw type, do not correspond to a case
+
+	test := func(rawType sema.Type, invalidRawValues ...string) {
+
+		t.Run(rawType.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			invalidChecks := make([]string, 0, len(invalidRawValues))
+			for _, rawValue := range invalidRawValues {
+				invalidChecks = append(
+					invalidChecks,
+					fmt.Sprintf("E(rawValue: %s) == nil", rawValue),
+				)
+			}
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      enum E: %[1]s {
+                          case a
+                          case b
+                          case c
+                      }
+
+                      fun roundTrip(): Bool {
+                          for e in [E.a, E.b, E.c] {
+                              let rawValue: %[1]s = e.rawValue
+                              if E(rawValue: rawValue) != e {
+                                  return false
+                              }
+                          }
+                          return true
+                      }
+
+                      let rawValues = [E.a.rawValue, E.b.rawValue, E.c.rawValue]
+
+                      let invalid = [%[2]s]
+                    `,
+					rawType,
+					strings.Join(invalidChecks, ", "),
+				),
+			)
+
+			result, err := inter.Invoke("roundTrip")
+			require.NoError(t, err)
+			assert.Equal(t, interpreter.TrueValue, result)
+
+			rawValues := inter.Globals.Get("rawValues").GetValue().(*interpreter.ArrayValue)
+			require.Equal(t, 3, rawValues.Count())
+
+			for i := 0; i < rawValues.Count(); i++ {
+				rawValue := rawValues.Get(inter, interpreter.EmptyLocationRange, i)
+				assert.Equal(t,
+					i,
+					rawValue.(interpreter.NumberValue).ToInt(interpreter.EmptyLocationRange),
+				)
+			}
+
+			invalid := inter.Globals.Get("invalid").GetValue().(*interpreter.ArrayValue)
+			require.Equal(t, len(invalidRawValues), invalid.Count())
+
+			for i := 0; i < invalid.Count(); i++ {
+				assert.Equal(t,
+					interpreter.TrueValue,
+					invalid.Get(inter, interpreter.EmptyLocationRange, i),
+					"raw value %s", invalidRawValues[i],
+				)
+			}
+		})
+	}
+
+	test(sema.UInt8Type, "3", "UInt8.max")
+	test(sema.Int8Type, "3", "-1", "Int8.min", "Int8.max")
+	test(sema.UInt64Type, "3", "UInt64.max")
+	test(sema.Int64Type, "3", "-1", "Int64.min", "Int64.max")
+	test(sema.UInt256Type, "3", "UInt256.max")
+	test(sema.IntType, "3", "-1", "1_000_000_000_000_000_000_000")
+	test(sema.Word8Type, "3", "Word8.max")
+}
+
 func TestInterpretEnumInstance(t *testing.T) {
 
 	t.Parallel()
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/fixedpoint"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 	. "github.com/onflow/cadence/runtime/tests/utils"
@@ -716,3 +717,130 @@ func TestStringFixedpointConversion(t *testing.T) {
 	}
 
 }
+
+func TestInterpretFixedPointRounding(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		expression string
+		expected   uint64
+	}
+
+	testCases := []testCase{
+		{"(0.0).floor()", 0},
+		{"(0.0).ceil()", 0},
+		{"(0.0).round(places: 0)", 0},
+		{"(1.5).floor()", 1_00000000},
+		{"(1.5).ceil()", 2_00000000},
+		{"(1.0).ceil()", 1_00000000},
+		{"(0.00000001).ceil()", 1_00000000},
+		{"(1.49999999).round(places: 0)", 1_00000000},
+		{"(1.5).round(places: 0)", 2_00000000},
+		{"(2.5).round(places: 0)", 3_00000000},
+		{"(1.23456789).round(places: 2)", 1_23000000},
+		{"(1.23556789).round(places: 2)", 1_24000000},
+		{"(1.23456789).round(places: 7)", 1_23456790},
+		{"(1.23456789).round(places: 8)", 1_23456789},
+		{"(1.23456789).round(places: 255)", 1_23456789},
+		{"UFix64.max.floor()", 184467440737_00000000},
+		{"UFix64.max.round(places: 0)", 184467440737_00000000},
+		{"UFix64.max.round(places: 6)", 184467440737_09551600},
+		{"UFix64.max.round(places: 8)", math.MaxUint64},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.expression, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): UFix64 {
+                          return %s
+                      }
+                    `,
+					testCase.expression,
+				),
+			)
+
+			result, err := inter.Invoke("test")
+			require.NoError(t, err)
+
+			RequireValuesEqual(
+				t,
+				inter,
+				interpreter.NewUnmeteredUFix64Value(testCase.expected),
+				result,
+			)
+		})
+	}
+
+	for _, expression := range []string{
+		"UFix64.max.ceil()",
+		"UFix64.max.round(places: 1)",
+		"UFix64.max.round(places: 7)",
+	} {
+		expression := expression
+
+		t.Run(expression, func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): UFix64 {
+                          return %s
+                      }
+                    `,
+					expression,
+				),
+			)
+
+			_, err := inter.Invoke("test")
+			RequireError(t, err)
+
+			require.ErrorAs(t, err, &interpreter.OverflowError{})
+		})
+	}
+
+	t.Run("function types", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [Bool] {
+              let value = 1.5
+              return [
+                  value.floor.getType() == Type<((): UFix64)>(),
+                  value.ceil.getType() == Type<((): UFix64)>(),
+                  value.round.getType() == Type<((UInt8): UFix64)>()
+              ]
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+				common.ZeroAddress,
+				interpreter.TrueValue,
+				interpreter.TrueValue,
+				interpreter.TrueValue,
+			),
+			result,
+		)
+	})
+}
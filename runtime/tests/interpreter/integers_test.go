@@ -760,6 +760,113 @@ func TestInterpretIntegerConversion(t *testing.T) {
 	}
 }
 
+func TestInterpretIntegerConversionError(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		sourceType    sema.Type
+		targetType    sema.Type
+		value         string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{sema.IntType, sema.UInt8Type, "256", interpreter.OverflowError{}},
+		{sema.IntType, sema.UInt8Type, "-1", interpreter.UnderflowError{}},
+		{sema.IntType, sema.Int8Type, "128", interpreter.OverflowError{}},
+		{sema.IntType, sema.Int8Type, "-129", interpreter.UnderflowError{}},
+		{sema.UInt16Type, sema.UInt8Type, "256", interpreter.OverflowError{}},
+		{sema.Int16Type, sema.UInt8Type, "-1", interpreter.UnderflowError{}},
+		{sema.UInt32Type, sema.Int16Type, "32768", interpreter.OverflowError{}},
+		{sema.Int64Type, sema.UInt32Type, "-1", interpreter.UnderflowError{}},
+		{sema.UInt64Type, sema.Int64Type, "18446744073709551615", interpreter.OverflowError{}},
+		{sema.Int128Type, sema.UInt64Type, "-1", interpreter.UnderflowError{}},
+		{sema.UInt256Type, sema.UInt128Type, "340282366920938463463374607431768211456", interpreter.OverflowError{}},
+		{sema.Int256Type, sema.Int128Type, "-170141183460469231731687303715884105729", interpreter.UnderflowError{}},
+		{sema.IntType, sema.UIntType, "-1", interpreter.UnderflowError{}},
+	}
+
+	for _, testCase := range testCases {
+
+		// NOTE: declare in loop, as captured in closure below
+		testCase := testCase
+
+		t.Run(fmt.Sprintf("%s to %s", testCase.sourceType, testCase.targetType), func(t *testing.T) {
+
+			t.Parallel()
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun test(): %[2]s {
+                          let value: %[1]s = %[3]s
+                          return %[2]s(value)
+                      }
+					`,
+					testCase.sourceType,
+					testCase.targetType,
+					testCase.value,
+				),
+			)
+
+			_, err := inter.Invoke("test")
+			RequireError(t, err)
+
+			var conversionErr interpreter.ConversionError
+			require.ErrorAs(t, err, &conversionErr)
+
+			assert.Equal(t,
+				interpreter.ConvertSemaToStaticType(nil, testCase.sourceType),
+				conversionErr.SourceType,
+			)
+			assert.Equal(t,
+				interpreter.ConvertSemaToStaticType(nil, testCase.targetType),
+				conversionErr.TargetType,
+			)
+			assert.IsType(t, testCase.expectedError, conversionErr.Err)
+
+			assert.Equal(t,
+				fmt.Sprintf(
+					"cannot convert value of type `%s` to type `%s`: %s",
+					testCase.sourceType,
+					testCase.targetType,
+					testCase.expectedError,
+				),
+				conversionErr.Error(),
+			)
+		})
+	}
+}
+
+func TestIntegerConversionErrorWithoutInterpreter(t *testing.T) {
+
+	t.Parallel()
+
+	// Conversions which are not performed by an interpreter,
+	// e.g. of literal arguments, report the same conversion error
+
+	var conversionErr interpreter.ConversionError
+
+	func() {
+		defer func() {
+			err, ok := recover().(error)
+			require.True(t, ok)
+			require.ErrorAs(t, err, &conversionErr)
+		}()
+
+		interpreter.ConvertUInt8(
+			nil,
+			interpreter.NewUnmeteredIntValueFromInt64(256),
+			interpreter.EmptyLocationRange,
+		)
+	}()
+
+	assert.Equal(t, interpreter.PrimitiveStaticTypeInt, conversionErr.SourceType)
+	assert.Equal(t, interpreter.PrimitiveStaticTypeUInt8, conversionErr.TargetType)
+	assert.IsType(t, interpreter.OverflowError{}, conversionErr.Err)
+}
+
 func TestInterpretIntegerMinMax(t *testing.T) {
 
 	t.Parallel()
@@ -6940,6 +6940,96 @@ func (v testValue) String() string {
 	return v.literal
 }
 
+func TestInterpretEmitEventInDestructor(t *testing.T) {
+
+	t.Parallel()
+
+	type emittedEvent struct {
+		typeID common.TypeID
+		id     interpreter.Value
+	}
+
+	var actualEvents []emittedEvent
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          event Before()
+          event Destroyed(id: Int)
+          event After()
+
+          resource R {
+              let id: Int
+              let inner: @R?
+
+              init(id: Int, inner: @R?) {
+                  self.id = id
+                  self.inner <- inner
+              }
+
+              destroy() {
+                  emit Destroyed(id: self.id)
+                  destroy self.inner
+              }
+          }
+
+          fun test() {
+              let r <- create R(id: 1, inner: <- create R(id: 2, inner: nil))
+              emit Before()
+              destroy r
+              emit After()
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Config: &interpreter.Config{
+				OnEventEmitted: func(
+					inter *interpreter.Interpreter,
+					_ interpreter.LocationRange,
+					event *interpreter.CompositeValue,
+					eventType *sema.CompositeType,
+				) error {
+					var id interpreter.Value
+					if eventType.Identifier == "Destroyed" {
+						id = event.GetField(inter, interpreter.EmptyLocationRange, "id")
+					}
+
+					actualEvents = append(
+						actualEvents,
+						emittedEvent{
+							typeID: eventType.ID(),
+							id:     id,
+						},
+					)
+					return nil
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]emittedEvent{
+			{
+				typeID: TestLocation.TypeID(nil, "Before"),
+			},
+			{
+				typeID: TestLocation.TypeID(nil, "Destroyed"),
+				id:     interpreter.NewUnmeteredIntValueFromInt64(1),
+			},
+			{
+				typeID: TestLocation.TypeID(nil, "Destroyed"),
+				id:     interpreter.NewUnmeteredIntValueFromInt64(2),
+			},
+			{
+				typeID: TestLocation.TypeID(nil, "After"),
+			},
+		},
+		actualEvents,
+	)
+}
+
 func TestInterpretEmitEventParameterTypes(t *testing.T) {
 
 	t.Parallel()
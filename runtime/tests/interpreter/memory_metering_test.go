@@ -6827,6 +6827,66 @@ func TestInterpretStringMetering(t *testing.T) {
 		// 1 + 4 (max UTF8 encoding)
 		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
 	})
+
+	t.Run("split", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub fun main() {
+                let x = "a,bc".split(separator: ",")
+            }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// (1 + 1 (a)) + (1 + 2 (bc))
+		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
+	})
+
+	t.Run("split, empty parts", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub fun main() {
+                let x = ",a,,".split(separator: ",").length
+            }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// The array is metered for all parts: "", "a", "", ""
+		assert.Equal(t, uint64(4), meter.getMemory(common.MemoryKindAtreeArrayElementOverhead))
+
+		// (1 + 0) + (1 + 1 (a)) + (1 + 0) + (1 + 0)
+		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
+	})
+
+	t.Run("join", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+            pub fun main() {
+                let x = String.join(["a", "bc"], separator: ", ")
+            }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// 1 + 5 (a, bc)
+		assert.Equal(t, uint64(6), meter.getMemory(common.MemoryKindStringValue))
+	})
 }
 
 func TestInterpretCharacterMetering(t *testing.T) {
@@ -6923,6 +6983,7 @@ func TestInterpretAddressValueMetering(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindAddressValue))
+		assert.Equal(t, uint64(8), meter.getMemory(common.MemoryKindNumberValue))
 	})
 }
 
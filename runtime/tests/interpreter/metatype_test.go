@@ -305,6 +305,257 @@ func TestInterpretMetaTypeIdentifier(t *testing.T) {
 	})
 }
 
+func TestInterpretMetaTypeElementAndKeyType(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("variable-sized array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let elementType = Type<[String]>().elementType
+          let keyType = Type<[String]>().keyType
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.TypeValue{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+			),
+			inter.Globals.Get("elementType").GetValue(),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.Nil,
+			inter.Globals.Get("keyType").GetValue(),
+		)
+	})
+
+	t.Run("constant-sized array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let result = Type<[Int; 2]>().elementType == Type<Int>()
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let type = Type<{String: Bool}>()
+          let elementType = type.elementType
+          let keyType = type.keyType
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.TypeValue{
+					Type: interpreter.PrimitiveStaticTypeBool,
+				},
+			),
+			inter.Globals.Get("elementType").GetValue(),
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredSomeValueNonCopying(
+				interpreter.TypeValue{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+			),
+			inter.Globals.Get("keyType").GetValue(),
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let type = Type<[[Int]]>()
+          let outer = type.elementType!
+          let inner = outer.elementType!
+          let result = outer == Type<[Int]>()
+              && inner == Type<Int>()
+              && inner.elementType == nil
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("non-container", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          let result = Type<S>().elementType == nil
+              && Type<S>().keyType == nil
+              && Type<[Int]?>().elementType == nil
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+
+		t.Parallel()
+
+		valueDeclaration := stdlib.StandardLibraryValue{
+			Name: "unknownType",
+			Type: sema.MetaType,
+			Value: interpreter.TypeValue{
+				Type: nil,
+			},
+			Kind: common.DeclarationKindConstant,
+		}
+
+		baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+		baseValueActivation.DeclareValue(valueDeclaration)
+
+		baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+		interpreter.Declare(baseActivation, valueDeclaration)
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              let result = unknownType.elementType == nil
+                  && unknownType.keyType == nil
+            `,
+			ParseCheckAndInterpretOptions{
+				CheckerConfig: &sema.Config{
+					BaseValueActivation: baseValueActivation,
+				},
+				Config: &interpreter.Config{
+					BaseActivation: baseActivation,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+}
+
+func TestInterpretMetaTypeDictionaryKey(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("registry", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          let registry: {Type: AnyStruct} = {
+              Type<Int>(): 1,
+              Type<[Int]>(): "array",
+              Type<{String: Int}>(): "dictionary",
+              Type<S>(): S(),
+              Type<Int?>(): true
+          }
+
+          let result = registry[(1).getType()]! as! Int == 1
+              && registry[[1, 2].getType()]! as! String == "array"
+              && registry[{"a": 1}.getType()]! as! String == "dictionary"
+              && registry[S().getType()]!.getType() == Type<S>()
+              && registry[Type<Int?>()]! as! Bool
+              && registry[Type<String>()] == nil
+              && registry.keys.length == 5
+        `)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+
+		t.Parallel()
+
+		valueDeclaration := stdlib.StandardLibraryValue{
+			Name: "unknownType",
+			Type: sema.MetaType,
+			Value: interpreter.TypeValue{
+				Type: nil,
+			},
+			Kind: common.DeclarationKindConstant,
+		}
+
+		baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+		baseValueActivation.DeclareValue(valueDeclaration)
+
+		baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+		interpreter.Declare(baseActivation, valueDeclaration)
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              let registry: {Type: AnyStruct} = {
+                  Type<Int>(): 1
+              }
+
+              // Unknown types are never equal to another type
+              let result = registry[unknownType] == nil
+                  && registry[Type<Int>()]! as! Int == 1
+            `,
+			ParseCheckAndInterpretOptions{
+				CheckerConfig: &sema.Config{
+					BaseValueActivation: baseValueActivation,
+				},
+				Config: &interpreter.Config{
+					BaseActivation: baseActivation,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get("result").GetValue(),
+		)
+	})
+}
+
 func TestInterpretIsInstance(t *testing.T) {
 
 	t.Parallel()
@@ -382,6 +633,56 @@ func TestInterpretIsInstance(t *testing.T) {
             `,
 			result: false,
 		},
+		{
+			name: "resource typed as AnyResource is an instance of its dynamic type",
+			code: `
+              resource R {}
+
+              let r: @AnyResource <- create R()
+              let rType = Type<@R>()
+              let result = r.isInstance(rType)
+            `,
+			result: true,
+		},
+		{
+			name: "resource is an instance of restricted type with conforming interface",
+			code: `
+              resource interface I {}
+
+              resource R: I {}
+
+              let r: @AnyResource <- create R()
+              let iType = Type<@AnyResource{I}>()
+              let result = r.isInstance(iType)
+            `,
+			result: true,
+		},
+		{
+			name: "resource is not an instance of restricted type with non-conforming interface",
+			code: `
+              resource interface I {}
+
+              resource R {}
+
+              let r: @AnyResource <- create R()
+              let iType = Type<@AnyResource{I}>()
+              let result = r.isInstance(iType)
+            `,
+			result: false,
+		},
+		{
+			name: "struct is an instance of restricted type with conforming interface",
+			code: `
+              struct interface I {}
+
+              struct S: I {}
+
+              let s: AnyStruct = S()
+              let iType = Type<AnyStruct{I}>()
+              let result = s.isInstance(iType)
+            `,
+			result: true,
+		},
 		{
 			name: "struct S is not an instance of an unknown type",
 			code: `
@@ -723,6 +1024,95 @@ func TestInterpretGetType(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "array, compared to type",
+			code: `
+              fun test(): Bool {
+                  return [1, 3].getType() == Type<[Int]>()
+              }
+            `,
+			result: interpreter.TrueValue,
+		},
+		{
+			name: "nested array",
+			code: `
+              fun test(): Type {
+                  let array: [[String]] = [["a"], []]
+                  return array.getType()
+              }
+            `,
+			result: interpreter.TypeValue{
+				Type: interpreter.VariableSizedStaticType{
+					Type: interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeString,
+					},
+				},
+			},
+		},
+		{
+			name: "constant-sized array",
+			code: `
+              fun test(): Type {
+                  let array: [Int8; 2] = [1, 3]
+                  return array.getType()
+              }
+            `,
+			result: interpreter.TypeValue{
+				Type: interpreter.ConstantSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt8,
+					Size: 2,
+				},
+			},
+		},
+		{
+			name: "dictionary",
+			code: `
+              fun test(): Type {
+                  return {"a": 1}.getType()
+              }
+            `,
+			result: interpreter.TypeValue{
+				Type: interpreter.DictionaryStaticType{
+					KeyType:   interpreter.PrimitiveStaticTypeString,
+					ValueType: interpreter.PrimitiveStaticTypeInt,
+				},
+			},
+		},
+		{
+			name: "dictionary, compared to type",
+			code: `
+              fun test(): Bool {
+                  return {"a": 1}.getType() == Type<{String: Int}>()
+              }
+            `,
+			result: interpreter.TrueValue,
+		},
+		{
+			name: "struct",
+			code: `
+              struct S {}
+
+              fun test(): Type {
+                  return S().getType()
+              }
+            `,
+			result: interpreter.TypeValue{
+				Type: interpreter.NewCompositeStaticTypeComputeTypeID(nil, TestLocation, "S"),
+			},
+		},
+		{
+			// the dynamic type is returned, not the static type
+			name: "struct, as AnyStruct",
+			code: `
+              struct S {}
+
+              fun test(): Bool {
+                  let value: AnyStruct = S()
+                  return value.getType() == Type<S>()
+              }
+            `,
+			result: interpreter.TrueValue,
+		},
 	}
 
 	for _, testCase := range cases {
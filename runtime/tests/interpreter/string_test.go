@@ -327,6 +327,229 @@ func TestInterpretStringToLower(t *testing.T) {
 	)
 }
 
+func TestInterpretStringSplit(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, str string, separator string, expected []string) {
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(str: String, separator: String): [String] {
+              return str.split(separator: separator)
+          }
+        `)
+
+		result, err := inter.Invoke(
+			"test",
+			interpreter.NewUnmeteredStringValue(str),
+			interpreter.NewUnmeteredStringValue(separator),
+		)
+		require.NoError(t, err)
+
+		expectedValues := make([]interpreter.Value, 0, len(expected))
+		for _, part := range expected {
+			expectedValues = append(expectedValues, interpreter.NewUnmeteredStringValue(part))
+		}
+
+		RequireValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeString,
+				},
+				common.ZeroAddress,
+				expectedValues...,
+			),
+			result,
+		)
+	}
+
+	t.Run("single character separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "a,b,c", ",", []string{"a", "b", "c"})
+	})
+
+	t.Run("multi-character separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "a::b::::c", "::", []string{"a", "b", "", "c"})
+	})
+
+	t.Run("separator at start and end", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, ",a,", ",", []string{"", "a", ""})
+	})
+
+	t.Run("no separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "abc", ",", []string{"abc"})
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "", ",", []string{""})
+	})
+
+	t.Run("empty separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "a👪❤️", "", []string{"a", "👪", "❤️"})
+	})
+
+	t.Run("empty input and separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, "", "", []string{})
+	})
+}
+
+func TestInterpretStringJoin(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected string) {
+
+		inter := parseCheckAndInterpret(t,
+			fmt.Sprintf(
+				`
+                  fun test(): String {
+                      return %s
+                  }
+                `,
+				code,
+			),
+		)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Equal(t,
+			interpreter.NewUnmeteredStringValue(expected),
+			result,
+		)
+	}
+
+	t.Run("single character separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join(["a", "b", "c"], separator: ",")`, "a,b,c")
+	})
+
+	t.Run("multi-character separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join(["a", "", "c"], separator: "::")`, "a::::c")
+	})
+
+	t.Run("empty separator", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join(["a", "b"], separator: "")`, "ab")
+	})
+
+	t.Run("single string", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join(["a"], separator: ",")`, "a")
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join([], separator: ",")`, "")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join("a, b, c".split(separator: ", "), separator: ", ")`, "a, b, c")
+	})
+}
+
+func TestInterpretStringSplitAndJoinComputation(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected uint) {
+
+		var computation uint
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			fmt.Sprintf(
+				`
+                  fun test() {
+                      %s
+                  }
+                `,
+				code,
+			),
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					OnMeterComputation: func(compKind common.ComputationKind, intensity uint) {
+						if compKind == common.ComputationKindStringOperation {
+							computation += intensity
+						}
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.Equal(t, expected, computation)
+	}
+
+	t.Run("split", func(t *testing.T) {
+		t.Parallel()
+
+		// 5 bytes, 3 parts
+		test(t, `"a,b,c".split(separator: ",")`, 8)
+	})
+
+	t.Run("split, longer input", func(t *testing.T) {
+		t.Parallel()
+
+		// 11 bytes, 2 parts
+		test(t, `"aaaaa,bbbbb".split(separator: ",")`, 13)
+	})
+
+	t.Run("split, empty separator", func(t *testing.T) {
+		t.Parallel()
+
+		// 3 bytes, 3 parts
+		test(t, `"abc".split(separator: "")`, 6)
+	})
+
+	t.Run("join", func(t *testing.T) {
+		t.Parallel()
+
+		// 5 bytes, 3 parts
+		test(t, `String.join(["a", "b", "c"], separator: ",")`, 8)
+	})
+
+	t.Run("join, longer input", func(t *testing.T) {
+		t.Parallel()
+
+		// 11 bytes, 2 parts
+		test(t, `String.join(["aaaaa", "bbbbb"], separator: ",")`, 13)
+	})
+
+	t.Run("join, empty input", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, `String.join([], separator: ",")`, 0)
+	})
+}
+
 func TestInterpretStringAccess(t *testing.T) {
 
 	t.Parallel()
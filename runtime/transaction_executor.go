@@ -159,11 +159,12 @@ func (executor *interpreterTransactionExecutor) preprocess() (err error) {
 	authorizerCount := len(authorizers)
 
 	transactionParameterCount := len(transactionType.Parameters)
-	if argumentCount != transactionParameterCount {
-		err = InvalidEntryPointParameterCountError{
-			Expected: transactionParameterCount,
-			Actual:   argumentCount,
-		}
+	err = checkEntryPointArgumentCount(
+		argumentCount,
+		transactionParameterCount,
+		executor.runtime.defaultConfig.OptionalArgumentsEnabled,
+	)
+	if err != nil {
 		return newError(err, location, codesAndPrograms)
 	}
 
@@ -259,6 +260,7 @@ func (executor *interpreterTransactionExecutor) transactionExecutionFunction(
 			interpreter.EmptyLocationRange,
 			executor.script.Arguments,
 			executor.transactionType.Parameters,
+			executor.runtime.defaultConfig.OptionalArgumentsEnabled,
 		)
 		if err != nil {
 			return nil, err
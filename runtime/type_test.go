@@ -26,6 +26,7 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
+	. "github.com/onflow/cadence/runtime/tests/utils"
 )
 
 func TestRuntimeTypeStorage(t *testing.T) {
@@ -222,3 +223,86 @@ func TestRuntimeBlockFieldTypes(t *testing.T) {
 		)
 	})
 }
+
+func TestRuntimeContractTypeIdentifier(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      pub contract Foo {
+          pub struct Bar {}
+      }
+    `)
+
+	script := []byte(`
+      import Foo from 0x01
+
+      pub fun main(): [String] {
+          return [
+              Type<Foo>().identifier,
+              Type<Foo.Bar>().identifier,
+              Type<[Foo.Bar]>().identifier
+          ]
+      }
+    `)
+
+	deploy := DeploymentTransaction("Foo", contract)
+
+	var accountCode []byte
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(_ common.AddressLocation) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ common.AddressLocation, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error { return nil },
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: deploy,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	// Type identifiers are already fully qualified with the location
+	// of the declaring contract, including for nested types
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.ScriptLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.String("A.0000000000000001.Foo"),
+			cadence.String("A.0000000000000001.Foo.Bar"),
+			cadence.String("[A.0000000000000001.Foo.Bar]"),
+		}).WithType(cadence.NewVariableSizedArrayType(cadence.StringType{})),
+		result,
+	)
+}